@@ -190,11 +190,56 @@ type EstructuraTid struct {
 	TID uint32
 }
 type EstructuraTiempo struct {
-	MS int
+	Dispositivo string // Vacío para el dispositivo por defecto del kernel
+	MS          int
 }
 type EstructuraRecurso struct {
 	Recurso string
 }
+type EstructuraSemaforo struct {
+	Recurso string
+	Valor   int // Valor inicial del contador
+}
+type EstructuraCondicion struct {
+	Recurso string
+	Mutex   string // Mutex que se libera mientras se espera la condición
+}
+type EstructuraBarrera struct {
+	Recurso  string
+	Cantidad int // Hilos que tienen que llegar para liberarla
+}
+type EstructuraPedidoRecurso struct {
+	Recurso  string
+	Cantidad int // Instancias que se declaran, piden o liberan
+}
+type EstructuraMensaje struct {
+	Cola  string
+	Valor uint32 // Valor que se manda en MQ_SEND o que devuelve el kernel en MQ_RECV
+}
+type EstructuraSegmento struct {
+	Nombre  string
+	Tamanio int // Solo en SHM_CREATE
+}
+type EstructuraProceso struct {
+	PID    uint32
+	Estado int // Estado de salida, en la respuesta de PROCESS_WAIT
+}
+type EstructuraSalida struct {
+	Codigo int // Código de salida de PROCESS_EXIT y THREAD_EXIT (0 si no se indica)
+}
+type EstructuraSenial struct {
+	PID uint32
+	TID uint32 // Solo en THREAD_KILL
+}
+type EstructuraTickets struct {
+	Tickets int
+}
+type EstructuraTiempoReal struct {
+	TID     uint32
+	Periodo int
+	Plazo   int
+	WCET    int
+}
 
 // Función Execute para ejecutar la instrucción decodificada
 func Execute(operacion string, args []string, logger *slog.Logger) {
@@ -281,17 +326,20 @@ func Execute(operacion string, args []string, logger *slog.Logger) {
 		//AnteriorPIDTID = GlobalPIDTID
 
 		utils.Control = false //! OJO
-		client.CederControlAKernell(dumpMemory, "DUMP_MEMORY", logger)
+		client.CederControlAKernell(dumpMemory, GlobalPIDTID, "DUMP_MEMORY", logger)
 
 	case "IO":
 
-		// Parseo los MS
-		ms := parcearArgs(args[0], logger)
+		// IO <ms> usa el dispositivo por defecto, IO <dispositivo> <ms> uno en particular
+		io := EstructuraTiempo{}
+		if len(args) > 1 {
+			io.Dispositivo = args[0]
+			io.MS = parcearArgs(args[1], logger)
+		} else {
+			io.MS = parcearArgs(args[0], logger)
+		}
 
 		//	Informar memoria
-		io := EstructuraTiempo{
-			MS: ms,
-		}
 		proceso.ContextoEjecucion.PC++
 
 		utils.Control = false //! OJO (creo que va asi porque cuando manda a io no sigue ejecutando el io)
@@ -316,7 +364,23 @@ func Execute(operacion string, args []string, logger *slog.Logger) {
 		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
 		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
 		//AnteriorPIDTID = GlobalPIDTID
-		client.CederControlAKernell(processCreate, "PROCESS_CREATE", logger)
+		// El kernel responde 202 con el PID del hijo; con un cuarto argumento queda en ese registro, para poder esperarlo con PROCESS_WAIT
+		var hijo EstructuraProceso
+		if CederControlConRespuesta(processCreate, "PROCESS_CREATE", &hijo, logger) && len(args) > 3 {
+			cpuInstruction.AsignarValorRegistro(args[3], hijo.PID, GlobalPIDTID.TID, logger)
+		}
+
+	case "PROCESS_WAIT":
+		// Informar memoria sin avanzar la PC: si el hijo sigue vivo el hilo se bloquea y al despertarse repite PROCESS_WAIT
+		processWait := EstructuraProceso{
+			PID: uint32(parcearArgs(args[0], logger)),
+		}
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		var hijo EstructuraProceso
+		if CederControlConRespuesta(processWait, "PROCESS_WAIT", &hijo, logger) {
+			cpuInstruction.AsignarValorRegistro(args[1], uint32(hijo.Estado), GlobalPIDTID.TID, logger)
+		}
 
 	case "THREAD_CREATE":
 		// Parsear la prioridad a entero
@@ -331,7 +395,7 @@ func Execute(operacion string, args []string, logger *slog.Logger) {
 		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
 		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
 		//AnteriorPIDTID = GlobalPIDTID
-		client.CederControlAKernell(threadCreate, "THREAD_CREATE", logger)
+		client.CederControlAKernell(threadCreate, GlobalPIDTID, "THREAD_CREATE", logger)
 
 	case "THREAD_JOIN":
 
@@ -362,8 +426,8 @@ func Execute(operacion string, args []string, logger *slog.Logger) {
 		proceso.ContextoEjecucion.PC++
 		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
 		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
-		//AnteriorPIDTID = GlobalPIDTID
-		client.CederControlAKernell(threadCancel, "THREAD_CANCEL", logger)
+		// Si el hilo se cancela a si mismo el kernel responde 200 y se corta el ciclo; sino sigue ejecutando
+		CederControlAKernell2(threadCancel, "THREAD_CANCEL", logger)
 
 	case "MUTEX_CREATE":
 		//	Informar memoria
@@ -374,7 +438,7 @@ func Execute(operacion string, args []string, logger *slog.Logger) {
 		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
 		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
 		//AnteriorPIDTID = GlobalPIDTID
-		client.CederControlAKernell(mutexCreate, "MUTEX_CREATE", logger)
+		client.CederControlAKernell(mutexCreate, GlobalPIDTID, "MUTEX_CREATE", logger)
 
 	case "MUTEX_LOCK":
 		//	Informar memoria
@@ -400,9 +464,222 @@ func Execute(operacion string, args []string, logger *slog.Logger) {
 		//AnteriorPIDTID = GlobalPIDTID
 		CederControlAKernell2(mutexUnlock, "MUTEX_UNLOCK", logger)
 
+	case "SEM_CREATE":
+		//	Informar memoria
+		semCreate := EstructuraSemaforo{
+			Recurso: args[0],
+			Valor:   parcearArgs(args[1], logger),
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		client.CederControlAKernell(semCreate, GlobalPIDTID, "SEM_CREATE", logger)
+
+	case "SEM_WAIT", "SEM_POST":
+		//	Informar memoria
+		semaforo := EstructuraRecurso{
+			Recurso: args[0],
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		CederControlAKernell2(semaforo, operacion, logger)
+
+	case "COND_CREATE":
+		//	Informar memoria
+		condCreate := EstructuraRecurso{
+			Recurso: args[0],
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		client.CederControlAKernell(condCreate, GlobalPIDTID, "COND_CREATE", logger)
+
+	case "COND_WAIT":
+		//	Informar memoria
+		condWait := EstructuraCondicion{
+			Recurso: args[0],
+			Mutex:   args[1],
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		CederControlAKernell2(condWait, "COND_WAIT", logger)
+
+	case "COND_SIGNAL", "COND_BROADCAST":
+		//	Informar memoria
+		condicion := EstructuraRecurso{
+			Recurso: args[0],
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		CederControlAKernell2(condicion, operacion, logger)
+
+	case "RWLOCK_CREATE":
+		//	Informar memoria
+		rwlockCreate := EstructuraRecurso{
+			Recurso: args[0],
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		client.CederControlAKernell(rwlockCreate, GlobalPIDTID, "RWLOCK_CREATE", logger)
+
+	case "RWLOCK_RDLOCK", "RWLOCK_WRLOCK", "RWLOCK_UNLOCK":
+		//	Informar memoria
+		rwlock := EstructuraRecurso{
+			Recurso: args[0],
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		CederControlAKernell2(rwlock, operacion, logger)
+
+	case "BARRIER_CREATE":
+		//	Informar memoria
+		barrierCreate := EstructuraBarrera{
+			Recurso:  args[0],
+			Cantidad: parcearArgs(args[1], logger),
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		client.CederControlAKernell(barrierCreate, GlobalPIDTID, "BARRIER_CREATE", logger)
+
+	case "BARRIER_WAIT":
+		//	Informar memoria
+		barrierWait := EstructuraRecurso{
+			Recurso: args[0],
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		CederControlAKernell2(barrierWait, "BARRIER_WAIT", logger)
+
+	case "RESOURCE_DECLARE", "RESOURCE_REQUEST", "RESOURCE_RELEASE":
+		//	Informar memoria
+		pedidoRecurso := EstructuraPedidoRecurso{
+			Recurso:  args[0],
+			Cantidad: parcearArgs(args[1], logger),
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		CederControlAKernell2(pedidoRecurso, operacion, logger)
+
+	case "MQ_OPEN":
+		//	Informar memoria
+		mqOpen := EstructuraMensaje{
+			Cola: args[0],
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		client.CederControlAKernell(mqOpen, GlobalPIDTID, "MQ_OPEN", logger)
+
+	case "MQ_SEND":
+		// Informar memoria sin avanzar la PC: si la cola está llena el hilo se bloquea y al despertarse repite MQ_SEND
+		mqSend := EstructuraMensaje{
+			Cola:  args[0],
+			Valor: cpuInstruction.ValorRegistro(args[1], logger),
+		}
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		CederControlAKernell2(mqSend, "MQ_SEND", logger)
+
+	case "MQ_RECV":
+		// Igual que MQ_SEND: si la cola está vacía el hilo se bloquea y al despertarse repite MQ_RECV
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		var mensaje EstructuraMensaje
+		if CederControlConRespuesta(EstructuraMensaje{Cola: args[0]}, "MQ_RECV", &mensaje, logger) {
+			cpuInstruction.AsignarValorRegistro(args[1], mensaje.Valor, GlobalPIDTID.TID, logger)
+		}
+
+	case "SHM_CREATE":
+		//	Informar memoria
+		shmCreate := EstructuraSegmento{
+			Nombre:  args[0],
+			Tamanio: parcearArgs(args[1], logger),
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		client.CederControlAKernell(shmCreate, GlobalPIDTID, "SHM_CREATE", logger)
+
+	case "SHM_ATTACH":
+		//	Informar memoria
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+
+		// El kernel devuelve donde quedó el segmento: la MMU lo usa para traducir y el registro recibe su dirección lógica
+		var segmento types.SegmentoAdjunto
+		if CederControlConRespuesta(EstructuraSegmento{Nombre: args[0]}, "SHM_ATTACH", &segmento, logger) {
+			client.ReceivedContextoEjecucion.Segmentos = append(client.ReceivedContextoEjecucion.Segmentos, segmento)
+			cpuInstruction.AsignarValorRegistro(args[1], segmento.Base, GlobalPIDTID.TID, logger)
+		}
+
+	case "PROCESS_KILL", "THREAD_KILL", "PROCESS_STOP", "PROCESS_CONT":
+		//	Informar memoria
+		senial := EstructuraSenial{
+			PID: uint32(parcearArgs(args[0], logger)),
+		}
+		if operacion == "THREAD_KILL" {
+			senial.TID = uint32(parcearArgs(args[1], logger))
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		// Si la señal afecta a este hilo el kernel lo saca con una interrupción
+		client.CederControlAKernell(senial, GlobalPIDTID, operacion, logger)
+
+	case "SET_TICKETS":
+
+		// Parseo la cantidad de tickets
+		tickets := parcearArgs(args[0], logger)
+
+		//	Informar memoria
+		setTickets := EstructuraTickets{
+			Tickets: tickets,
+		}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		client.CederControlAKernell(setTickets, GlobalPIDTID, "SET_TICKETS", logger)
+
+	case "THREAD_SET_RT":
+
+		// Parseo el TID, el periodo, el plazo y el WCET (en milisegundos)
+		threadSetRT := EstructuraTiempoReal{
+			TID:     uint32(parcearArgs(args[0], logger)),
+			Periodo: parcearArgs(args[1], logger),
+			Plazo:   parcearArgs(args[2], logger),
+			WCET:    parcearArgs(args[3], logger),
+		}
+
+		//	Informar memoria
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		client.CederControlAKernell(threadSetRT, GlobalPIDTID, "THREAD_SET_RT", logger)
+
+	case "THREAD_JOB_END":
+
+		// Un hilo periódico terminó su trabajo; si el siguiente todavia no se liberó el kernel lo bloquea
+		threadJobEnd := estructuraEmpty{}
+		proceso.ContextoEjecucion.PC++
+		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
+		CederControlAKernell2(threadJobEnd, "THREAD_JOB_END", logger)
+
 	case "THREAD_EXIT":
 		//	Informar memoria
-		threadExit := estructuraEmpty{}
+		threadExit := EstructuraSalida{}
+		if len(args) > 0 {
+			threadExit.Codigo = parcearArgs(args[0], logger)
+		}
 		proceso.ContextoEjecucion.PC++
 		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
 		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
@@ -411,7 +688,10 @@ func Execute(operacion string, args []string, logger *slog.Logger) {
 
 	case "PROCESS_EXIT":
 		//	Informar memoria
-		processExit := estructuraEmpty{}
+		processExit := EstructuraSalida{}
+		if len(args) > 0 {
+			processExit.Codigo = parcearArgs(args[0], logger)
+		}
 		proceso.ContextoEjecucion.PC++
 		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
 		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", GlobalPIDTID.TID))
@@ -419,7 +699,7 @@ func Execute(operacion string, args []string, logger *slog.Logger) {
 
 		// ROMPO EL CICLO YA QUE SIEMPRE VA A FINALIZAR EL PROCESO
 		utils.Control = false
-		client.CederControlAKernell(processExit, "PROCESS_EXIT", logger)
+		client.CederControlAKernell(processExit, GlobalPIDTID, "PROCESS_EXIT", logger)
 
 	default:
 		logger.Error(fmt.Sprintf("Operación desconocida: %s", operacion))
@@ -475,6 +755,40 @@ func parcearArgs(arg string, logger *slog.Logger) int {
 
 // PONGO ACA POR UN TEMA DE INCLUCIONES CIRCULARES
 
+// Igual que CederControlAKernell2, pero con 202 el kernel manda en el body el resultado de la syscall (MQ_RECV, SHM_ATTACH)
+// y se decodifica en respuesta. Retorna true si el hilo sigue ejecutando con la respuesta; con 200 el hilo quedó
+// bloqueado (o finalizado) y se corta el ciclo
+func CederControlConRespuesta[T any, R any](dato T, endpoint string, respuesta *R, logger *slog.Logger) bool {
+
+	body, err := json.Marshal(dato)
+	if err != nil {
+		logger.Error("Se produjo un error codificando el mensaje")
+		return false
+	}
+
+	resp, err := client.PostAlKernel(GlobalPIDTID, endpoint, body)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Se produjo un error enviando mensaje a ip:%s puerto:%d", utils.Configs.IpKernel, utils.Configs.PortKernel))
+		return false
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		if err := json.NewDecoder(resp.Body).Decode(respuesta); err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar la respuesta de %s: %s", endpoint, err.Error()))
+			return false
+		}
+		return true
+	case http.StatusOK:
+		utils.Control = false
+		GlobalPIDTID = types.PIDTID{TID: 10000, PID: 0}
+	default:
+		logger.Error("La respuesta del servidor no fue OK")
+	}
+	return false
+}
+
 func CederControlAKernell2[T any](dato T, endpoint string, logger *slog.Logger) {
 
 	body, err := json.Marshal(dato)
@@ -483,8 +797,7 @@ func CederControlAKernell2[T any](dato T, endpoint string, logger *slog.Logger)
 		return
 	}
 
-	url := fmt.Sprintf("http://%s:%d/%s", utils.Configs.IpKernel, utils.Configs.PortKernel, endpoint)
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	resp, err := client.PostAlKernel(GlobalPIDTID, endpoint, body)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Se produjo un error enviando mensaje a ip:%s puerto:%d", utils.Configs.IpKernel, utils.Configs.PortKernel))
 		return
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
 
 	"github.com/sisoputnfrba/tp-golang/cpu/utils"
 	"github.com/sisoputnfrba/tp-golang/utils/types"
@@ -110,7 +111,23 @@ func EnviarContextoDeEjecucion[T any](dato T, endpoint string, logger *slog.Logg
 	return true // Indica que la respuesta fue exitosa
 }
 
-func CederControlAKernell[T any](dato T, endpoint string, logger *slog.Logger) {
+// Hace un POST al kernel mandando en los headers el PID y TID del hilo que está ejecutando, asi el kernel sabe de que CPU viene la syscall
+func PostAlKernel(pidtid types.PIDTID, endpoint string, body []byte) (*http.Response, error) {
+	url := fmt.Sprintf("http://%s:%d/%s", utils.Configs.IpKernel, utils.Configs.PortKernel, endpoint)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("PID", strconv.Itoa(int(pidtid.PID)))
+	req.Header.Set("TID", strconv.Itoa(int(pidtid.TID)))
+
+	return http.DefaultClient.Do(req)
+}
+
+// Para las syscalls en las que el hilo sigue ejecutando; si el kernel responde HILO_FINALIZADO (el hilo se finalizó
+// antes de atenderla) se corta el ciclo
+func CederControlAKernell[T any](dato T, pidtid types.PIDTID, endpoint string, logger *slog.Logger) {
 
 	body, err := json.Marshal(dato)
 	if err != nil {
@@ -118,8 +135,7 @@ func CederControlAKernell[T any](dato T, endpoint string, logger *slog.Logger) {
 		return
 	}
 
-	url := fmt.Sprintf("http://%s:%d/%s", utils.Configs.IpKernel, utils.Configs.PortKernel, endpoint)
-	resp, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	resp, err := PostAlKernel(pidtid, endpoint, body)
 	if err != nil {
 		logger.Error(fmt.Sprintf("Se produjo un error enviando mensaje a ip:%s puerto:%d", utils.Configs.IpKernel, utils.Configs.PortKernel))
 		return
@@ -131,6 +147,11 @@ func CederControlAKernell[T any](dato T, endpoint string, logger *slog.Logger) {
 		logger.Error("La respuesta del servidor no fue OK")
 		return // Indica que la respuesta no fue exitosa
 	}
+
+	var respuesta string
+	if json.NewDecoder(resp.Body).Decode(&respuesta) == nil && respuesta == "HILO_FINALIZADO" {
+		utils.Control = false
+	}
 }
 
 // EnviarDesalojo envia el PID, TID y el motivo del desalojo a la API Kernel utilizando la configuración global de IP y puerto.
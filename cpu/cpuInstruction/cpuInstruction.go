@@ -118,6 +118,11 @@ func LogRegistro(registro string, pidtid types.PIDTID, logger *slog.Logger) {
 	logger.Info(fmt.Sprintf("## TID: %d - Ejecutando: LOG - Registro: %s, Valor: %d", pidtid.TID, registro, valor))
 }
 
+// Función para obtener el valor de un registro desde otro paquete (por ejemplo para mandarlo al kernel en MQ_SEND)
+func ValorRegistro(registro string, logger *slog.Logger) uint32 {
+	return obtenerValorRegistro(registro, logger)
+}
+
 // Función auxiliar para obtener el valor de un registro
 func obtenerValorRegistro(registro string, logger *slog.Logger) uint32 {
 	registros := client.ReceivedContextoEjecucion
@@ -10,19 +10,36 @@ import (
 	"github.com/sisoputnfrba/tp-golang/utils/types"
 )
 
-func TraducirDireccion(proceso *types.Proceso, direccionLogica uint32, logger *slog.Logger) (uint32, error) {
+// READ_MEM y WRITE_MEM leen y escriben 4 bytes, todos tienen que caer dentro del mismo segmento
+const TAMANIO_ACCESO = 4
 
-	direccionFisica := proceso.ContextoEjecucion.Base + direccionLogica
-	if direccionFisica >= proceso.ContextoEjecucion.Base+proceso.ContextoEjecucion.Limite {
+func TraducirDireccion(proceso *types.Proceso, direccionLogica uint32, logger *slog.Logger) (uint32, error) {
 
-		proceso.ContextoEjecucion.PC++
-		client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
-		logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", proceso.Tid))
-		client.EnviarDesalojo(proceso.Pid, proceso.Tid, "SEGMENTATION FAULT", logger)
-		log.Printf("Segmentation Fault en Tid %d", proceso.Tid)
+	// Las direcciones de los segmentos de memoria compartida se traducen con los datos de cada segmento
+	for _, segmento := range proceso.ContextoEjecucion.Segmentos {
+		if direccionLogica >= segmento.Base && direccionLogica < segmento.Base+segmento.Limite {
+			if direccionLogica+TAMANIO_ACCESO > segmento.Base+segmento.Limite {
+				return segmentationFault(proceso, logger)
+			}
+			return segmento.Fisica + direccionLogica - segmento.Base, nil
+		}
+	}
 
-		return 0, errors.New("segmentation fault")
+	direccionFisica := proceso.ContextoEjecucion.Base + direccionLogica
+	if direccionFisica+TAMANIO_ACCESO > proceso.ContextoEjecucion.Base+proceso.ContextoEjecucion.Limite {
+		return segmentationFault(proceso, logger)
 	}
 
 	return direccionFisica, nil
 }
+
+// Avisa al kernel del Segmentation Fault para que finalice el proceso
+func segmentationFault(proceso *types.Proceso, logger *slog.Logger) (uint32, error) {
+	proceso.ContextoEjecucion.PC++
+	client.EnviarContextoDeEjecucion(proceso, "actualizar_contexto", logger)
+	logger.Info(fmt.Sprintf("## TID: %d - Actualizo Contexto Ejecución", proceso.Tid))
+	client.EnviarDesalojo(proceso.Pid, proceso.Tid, "SEGMENTATION_FAULT", logger)
+	log.Printf("Segmentation Fault en Tid %d", proceso.Tid)
+
+	return 0, errors.New("segmentation fault")
+}
@@ -22,6 +22,7 @@ func Inicializar_cpu(logger *slog.Logger) {
 	mux.HandleFunc("POST /EJECUTAR_KERNEL", Recibir_PIDTID(logger))
 	mux.HandleFunc("POST /INTERRUPCION_FIN_QUANTUM", RecibirInterrupcion(logger))
 	mux.HandleFunc("POST /PRIORIDAD", RecibirInterrupcion(logger))
+	mux.HandleFunc("POST /INTERRUPCION", RecibirInterrupcion(logger))
 	//mux.HandleFunc("POST /comunicacion-memoria", ComunicacionMemoria(logger))
 
 	conexiones.LevantarServidor(strconv.Itoa(utils.Configs.Port), mux, logger)
@@ -99,6 +99,33 @@ func Enviar_QueryPath[T any](dato T, ip string, puerto int, endpoint string, ver
 	return true // Indica que la respuesta fue exitosa
 }
 
+// Hace el POST y, si la respuesta es OK, decodifica el body en respuesta. Devuelve el código de estado (0 si no se pudo enviar)
+func Enviar_Body_Con_Respuesta[T any, R any](dato T, ip string, puerto int, endpoint string, respuesta *R, logger *slog.Logger) int {
+
+	body, err := json.Marshal(dato)
+	if err != nil {
+		logger.Error("Se produjo un error codificando el mensaje")
+		return 0
+	}
+
+	url := fmt.Sprintf("http://%s:%d/%s", ip, puerto, endpoint)
+	resp, err := http.Post(url, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		logger.Error(fmt.Sprintf("Se produjo un error enviando mensaje a ip:%s puerto:%d", ip, puerto))
+		return 0
+	}
+	// Aseguramos que el body sea cerrado
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK && respuesta != nil {
+		if err := json.NewDecoder(resp.Body).Decode(respuesta); err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar la respuesta de %s: %s", endpoint, err.Error()))
+			return 0
+		}
+	}
+	return resp.StatusCode
+}
+
 func Enviar_Proceso[T any](dato T, ip string, puerto int, endpoint string, logger *slog.Logger) (bool, string) {
 
 	body, err := json.Marshal(dato)
@@ -0,0 +1,312 @@
+package consola
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/planificador"
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+)
+
+// -------------------------------------- CONSOLA DEL OPERADOR --------------------------------------
+
+// Lee comandos por stdin mientras el kernel está levantado. Cada comando se aplica dentro de un evento del núcleo,
+// igual que una syscall, asi que ve y modifica el estado sin pisarse con los hilos que están ejecutando.
+// Los que solo muestran el estado (ps, queues, stats) lo leen con una consulta, arman la salida en memoria y la
+// escriben cuando el núcleo ya siguió
+
+// Un comando de la consola; ejecutar recibe los argumentos ya separados y escribe el resultado en salida
+type comando struct {
+	uso      string
+	ayuda    string
+	minimo   int // Cantidad mínima de argumentos
+	ejecutar func(args []string, salida io.Writer, logger *slog.Logger) error
+}
+
+var comandos map[string]comando
+
+func init() {
+	comandos = map[string]comando{
+		"run":    {uso: "run ARCHIVO TAMAÑO [PRIORIDAD]", ayuda: "Crea un proceso", minimo: 2, ejecutar: run},
+		"ps":     {uso: "ps", ayuda: "Lista los procesos y el estado de sus hilos", ejecutar: ps},
+		"kill":   {uso: "kill PID [TID]", ayuda: "Finaliza el proceso (o solo el hilo)", minimo: 1, ejecutar: kill},
+		"stop":   {uso: "stop PID", ayuda: "Detiene el proceso", minimo: 1, ejecutar: senial("PROCESS_STOP")},
+		"cont":   {uso: "cont PID", ayuda: "Continúa un proceso detenido", minimo: 1, ejecutar: senial("PROCESS_CONT")},
+		"nice":   {uso: "nice PID TID PRIORIDAD", ayuda: "Cambia la prioridad de un hilo", minimo: 3, ejecutar: nice},
+		"queues": {uso: "queues", ayuda: "Muestra las colas de estados", ejecutar: queues},
+		"pause":  {uso: "pause", ayuda: "Pausa la planificación (los hilos que ejecutan siguen)", ejecutar: pausar(true)},
+		"resume": {uso: "resume", ayuda: "Reanuda la planificación", ejecutar: pausar(false)},
+		"stats":  {uso: "stats", ayuda: "Muestra estadísticas del kernel", ejecutar: stats},
+		"help":   {uso: "help", ayuda: "Muestra esta ayuda", ejecutar: help},
+	}
+}
+
+// Lee comandos de stdin hasta que se cierra; se llama en una goroutine aparte
+func Iniciar_consola(logger *slog.Logger) {
+	Leer_comandos(os.Stdin, os.Stdout, logger)
+}
+
+// Ejecuta cada línea de entrada como un comando y escribe el resultado (o el error) en salida
+func Leer_comandos(entrada io.Reader, salida io.Writer, logger *slog.Logger) {
+	scanner := bufio.NewScanner(entrada)
+	for scanner.Scan() {
+		campos := strings.Fields(scanner.Text())
+		if len(campos) == 0 {
+			continue
+		}
+
+		cmd, existe := comandos[campos[0]]
+		if !existe {
+			fmt.Fprintf(salida, "Comando desconocido: %s (help para ver los comandos)\n", campos[0])
+			continue
+		}
+		if len(campos)-1 < cmd.minimo {
+			fmt.Fprintf(salida, "Uso: %s\n", cmd.uso)
+			continue
+		}
+
+		logger.Info(fmt.Sprintf("## Consola: %s", strings.Join(campos, " ")))
+		if err := cmd.ejecutar(campos[1:], salida, logger); err != nil {
+			fmt.Fprintf(salida, "Error: %s\n", err.Error())
+		}
+	}
+}
+
+// Parsea los argumentos numéricos del comando
+func numeros(args []string) ([]int, error) {
+	valores := make([]int, len(args))
+	for i, arg := range args {
+		valor, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%s no es un número", arg)
+		}
+		valores[i] = valor
+	}
+	return valores, nil
+}
+
+func run(args []string, salida io.Writer, logger *slog.Logger) error {
+	if len(args) < 3 {
+		args = append(args, "0")
+	}
+	valores, err := numeros(args[1:3])
+	if err != nil {
+		return err
+	}
+	if valores[0] <= 0 {
+		return fmt.Errorf("el tamaño del proceso tiene que ser mayor a 0")
+	}
+
+	var pid uint32
+	planificador.Notificar(planificador.EventosReady, func() {
+		pid = planificador.Crear_proceso(args[0], valores[0], valores[1], 0, logger)
+	})
+	fmt.Fprintf(salida, "Proceso %d creado\n", pid)
+	return nil
+}
+
+func ps(args []string, salida io.Writer, logger *slog.Logger) error {
+	var foto bytes.Buffer
+	planificador.Consultar(func() {
+		tabla := tabwriter.NewWriter(&foto, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tabla, "PID\tPADRE\tESTADO\tTID\tPRIORIDAD\tESTADO HILO")
+		for _, pid := range pidsOrdenados() {
+			pcb := utils.Estado.MapaPCB[pid]
+			fmt.Fprintf(tabla, "%d\t%d\t%s\t\t\t\n", pid, pcb.Padre, utils.Estado.Estado_de_proceso(pid))
+
+			tids := make([]uint32, 0, len(pcb.TCBs))
+			for tid := range pcb.TCBs {
+				tids = append(tids, tid)
+			}
+			sort.Slice(tids, func(i, j int) bool { return tids[i] < tids[j] })
+			for _, tid := range tids {
+				fmt.Fprintf(tabla, "\t\t\t%d\t%d\t%s\n", tid, pcb.TCBs[tid].Prioridad, utils.Estado.Estado_de_hilo(pid, tid))
+			}
+		}
+		tabla.Flush()
+	})
+	return imprimir(&foto, salida)
+}
+
+// kill pasa por las señales para que, si el proceso tiene hilos ejecutando, se finalice cuando dejan la CPU
+func kill(args []string, salida io.Writer, logger *slog.Logger) error {
+	if len(args) > 1 {
+		return senial("THREAD_KILL")(args, salida, logger)
+	}
+	return senial("PROCESS_KILL")(args, salida, logger)
+}
+
+func senial(tipo string) func(args []string, salida io.Writer, logger *slog.Logger) error {
+	return func(args []string, salida io.Writer, logger *slog.Logger) error {
+		valores, err := numeros(args)
+		if err != nil {
+			return err
+		}
+		pedido := utils.Senial{Tipo: tipo, PID: uint32(valores[0])}
+		if tipo == "THREAD_KILL" {
+			pedido.TID = uint32(valores[1])
+		}
+
+		enviada := false
+		planificador.Notificar(planificador.EventosSyscall, func() {
+			enviada = planificador.Enviar_senial(pedido, logger)
+		})
+		if !enviada {
+			return fmt.Errorf("no existe el proceso o el hilo")
+		}
+		fmt.Fprintf(salida, "%s enviada al proceso %d\n", tipo, pedido.PID)
+		return nil
+	}
+}
+
+func nice(args []string, salida io.Writer, logger *slog.Logger) error {
+	valores, err := numeros(args[:3])
+	if err != nil {
+		return err
+	}
+	if valores[2] < 0 {
+		return fmt.Errorf("la prioridad no puede ser negativa")
+	}
+
+	cambiada := false
+	planificador.Notificar(planificador.EventosReady, func() {
+		cambiada = planificador.Cambiar_prioridad(uint32(valores[0]), uint32(valores[1]), valores[2], logger)
+	})
+	if !cambiada {
+		return fmt.Errorf("no existe el hilo")
+	}
+	fmt.Fprintf(salida, "Prioridad de (%d:%d) cambiada a %d\n", valores[0], valores[1], valores[2])
+	return nil
+}
+
+func queues(args []string, salida io.Writer, logger *slog.Logger) error {
+	var foto bytes.Buffer
+	planificador.Consultar(func() {
+		fmt.Fprintf(&foto, "NEW:")
+		for _, new := range utils.Estado.ColaNew {
+			fmt.Fprintf(&foto, " %d", new.PCB.PID)
+		}
+		fmt.Fprintln(&foto)
+
+		listos := planificador.Algoritmo.Listos()
+		niveles := make([]int, 0, len(listos))
+		for nivel := range listos {
+			niveles = append(niveles, nivel)
+		}
+		sort.Ints(niveles)
+		for _, nivel := range niveles {
+			fmt.Fprintf(&foto, "READY %d:", nivel)
+			for _, tcb := range listos[nivel] {
+				fmt.Fprintf(&foto, " (%d:%d)", tcb.PID, tcb.TID)
+			}
+			fmt.Fprintln(&foto)
+		}
+
+		fmt.Fprintf(&foto, "EXEC:")
+		for _, exec := range utils.Hilos_ejecutando() {
+			fmt.Fprintf(&foto, " (%d:%d) en CPU %d", exec.PID, exec.TID, exec.CPU)
+		}
+		fmt.Fprintln(&foto)
+
+		fmt.Fprintf(&foto, "BLOCKED:")
+		for _, bloqueado := range utils.Estado.ColaBlocked {
+			fmt.Fprintf(&foto, " (%d:%d) %s %s", bloqueado.PID, bloqueado.TID, bloqueado.Motivo, bloqueado.QuienFue)
+		}
+		fmt.Fprintln(&foto)
+
+		fmt.Fprintf(&foto, "STOPPED:")
+		for _, tcb := range utils.Estado.ColaStopped {
+			fmt.Fprintf(&foto, " (%d:%d)", tcb.PID, tcb.TID)
+		}
+		fmt.Fprintln(&foto)
+
+		fmt.Fprintf(&foto, "EXIT: %d hilos finalizados\n", len(utils.Estado.ColaExit))
+	})
+	return imprimir(&foto, salida)
+}
+
+func pausar(pausar bool) func(args []string, salida io.Writer, logger *slog.Logger) error {
+	return func(args []string, salida io.Writer, logger *slog.Logger) error {
+		planificador.Notificar(planificador.EventosSyscall, func() {
+			planificador.Pausado = pausar
+		})
+		if pausar {
+			fmt.Fprintln(salida, "Planificación pausada")
+		} else {
+			fmt.Fprintln(salida, "Planificación reanudada")
+		}
+		return nil
+	}
+}
+
+func stats(args []string, salida io.Writer, logger *slog.Logger) error {
+	var foto bytes.Buffer
+	planificador.Consultar(func() {
+		hilos, tiempoCPU := 0, 0.0
+		for _, pcb := range utils.Estado.MapaPCB {
+			hilos += len(pcb.TCBs)
+			for _, tcb := range pcb.TCBs {
+				tiempoCPU += tcb.TiempoCPU
+			}
+		}
+		motivos := make(map[string]int)
+		for _, hilo := range utils.Estado.ColaExit {
+			tiempoCPU += hilo.TiempoCPU
+			motivos[hilo.Motivo]++
+		}
+
+		fmt.Fprintf(&foto, "Planificador: %s (pausado: %t)\n", utils.Configs.SchedulerAlgorithm, planificador.Pausado)
+		fmt.Fprintf(&foto, "Procesos: %d vivos, %d en NEW, %d detenidos, %d finalizados, %d zombies\n",
+			len(utils.Estado.MapaPCB), len(utils.Estado.ColaNew), len(utils.Estado.Detenidos), len(utils.Estado.Finalizados), len(utils.Estado.Zombies))
+		fmt.Fprintf(&foto, "Hilos: %d vivos, %d bloqueados, %d detenidos, %d finalizados\n",
+			hilos, len(utils.Estado.ColaBlocked), len(utils.Estado.ColaStopped), len(utils.Estado.ColaExit))
+		fmt.Fprintf(&foto, "CPUs: %d de %d ocupadas, %d despachos\n", len(utils.Hilos_ejecutando()), len(utils.Estado.Executes), planificador.ExecuteContador)
+		fmt.Fprintf(&foto, "Tiempo de CPU: %.0f ms\n", tiempoCPU)
+		nombres := make([]string, 0, len(motivos))
+		for motivo := range motivos {
+			nombres = append(nombres, motivo)
+		}
+		sort.Strings(nombres)
+		for _, motivo := range nombres {
+			fmt.Fprintf(&foto, "Hilos finalizados por %s: %d\n", motivo, motivos[motivo])
+		}
+	})
+	return imprimir(&foto, salida)
+}
+
+func help(args []string, salida io.Writer, logger *slog.Logger) error {
+	nombres := make([]string, 0, len(comandos))
+	for nombre := range comandos {
+		nombres = append(nombres, nombre)
+	}
+	sort.Strings(nombres)
+
+	tabla := tabwriter.NewWriter(salida, 0, 4, 2, ' ', 0)
+	for _, nombre := range nombres {
+		fmt.Fprintf(tabla, "%s\t%s\n", comandos[nombre].uso, comandos[nombre].ayuda)
+	}
+	return tabla.Flush()
+}
+
+// Escribe la foto armada dentro del núcleo; se llama despues de la consulta, asi una terminal lenta no frena al núcleo
+func imprimir(foto *bytes.Buffer, salida io.Writer) error {
+	_, err := foto.WriteTo(salida)
+	return err
+}
+
+func pidsOrdenados() []uint32 {
+	pids := make([]uint32, 0, len(utils.Estado.MapaPCB))
+	for pid := range utils.Estado.MapaPCB {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool { return pids[i] < pids[j] })
+	return pids
+}
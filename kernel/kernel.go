@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 
+	"github.com/sisoputnfrba/tp-golang/kernel/consola"
 	"github.com/sisoputnfrba/tp-golang/kernel/planificador"
 	"github.com/sisoputnfrba/tp-golang/kernel/server"
 	"github.com/sisoputnfrba/tp-golang/kernel/utils"
@@ -23,22 +24,24 @@ func main() {
 	// Inicializamos el planificador
 	planificador.Iniciar_planificador(utils.Configs, logger)
 
-	// Inicializamos el mapa de PCBs
-	utils.InicializarPCBMapGlobal()
-
-	// Obtener los parametros del primer proceso a ejecutar
-	archivoPseudocodigo := os.Args[1]
-	tamanioProceso, err := strconv.Atoi(os.Args[2])
-	if err != nil {
-		fmt.Println("Error: El tamaño del proceso debe ser un número entero.")
-		panic(err)
+	// El proceso inicial es opcional: sin argumentos se crean los procesos desde la consola con run
+	if len(os.Args) > 2 {
+		// Obtener los parametros del primer proceso a ejecutar
+		archivoPseudocodigo := os.Args[1]
+		tamanioProceso, err := strconv.Atoi(os.Args[2])
+		if err != nil {
+			fmt.Println("Error: El tamaño del proceso debe ser un número entero.")
+			panic(err)
+		}
+
+		// Creación del proceso inicial, que adopta a los huérfanos
+		planificador.Notificar(planificador.EventosReady, func() {
+			planificador.PIDInit = planificador.Crear_proceso(archivoPseudocodigo, tamanioProceso, 0, 0, logger)
+		})
 	}
 
-	// Creación del proceso inicial
-	planificador.Crear_proceso(archivoPseudocodigo, tamanioProceso, 0, logger)
-
-	// Inicializamos la cola de IO
-	go planificador.Procesar_cola_IO(&planificador.ColaIO, logger)
+	// Consola del operador por stdin
+	go consola.Iniciar_consola(logger)
 
 	// Iniciamos Kernel como server
 	server.Iniciar_kernel(logger)
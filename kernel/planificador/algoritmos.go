@@ -0,0 +1,114 @@
+package planificador
+
+import (
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+func init() {
+	Registrar_algoritmo("FIFO", func() Scheduler { return fifo{} })
+	Registrar_algoritmo("PRIORIDADES", func() Scheduler { return prioridades{} })
+	Registrar_algoritmo("CMN", func() Scheduler { return colasMultinivel{} })
+}
+
+// FIFO: una sola cola (nivel 0), sin desalojo ni quantum
+type fifo struct{}
+
+func (fifo) Encolar(tcb types.TCB) {
+	utils.Encolar_ColaReady(utils.Estado.ColaReady, 0, tcb)
+}
+
+func (fifo) Proximo() (types.TCB, bool) {
+	if len(utils.Estado.ColaReady[0]) == 0 {
+		return types.TCB{}, false
+	}
+	return utils.Estado.ColaReady[0][0], true
+}
+
+func (fifo) Quitar(pid uint32, tid uint32) bool {
+	return utils.Quitar_TCB_de_ColaReady(utils.Estado.ColaReady, pid, tid)
+}
+
+func (fifo) DebeDesalojar(candidato types.TCB, actual types.TCB) bool {
+	return false
+}
+
+func (fifo) Quantum(tcb types.TCB) time.Duration {
+	return 0
+}
+
+func (f fifo) FinDeQuantum(tcb types.TCB) {
+	f.Encolar(tcb)
+}
+
+func (fifo) Listos() map[int][]types.TCB {
+	return utils.Copiar_ColaReady(utils.Estado.ColaReady)
+}
+
+// PRIORIDADES: una sola cola (nivel 0), se elige el de menor numero de prioridad y desaloja al que está ejecutando si es mas prioritario
+type prioridades struct {
+	fifo
+}
+
+func (prioridades) Proximo() (types.TCB, bool) {
+	if len(utils.Estado.ColaReady[0]) == 0 {
+		return types.TCB{}, false
+	}
+	siguienteHilo := utils.Estado.ColaReady[0][0]
+	// Vamos buscando el hilo de menor prioridad (esto a su vez cumple que si hay otro de igual prioridad, desempata por el primero que llegó)
+	for _, tcb := range utils.Estado.ColaReady[0] {
+		if tcb.Prioridad < siguienteHilo.Prioridad {
+			siguienteHilo = tcb
+		}
+	}
+	return siguienteHilo, true
+}
+
+func (prioridades) DebeDesalojar(candidato types.TCB, actual types.TCB) bool {
+	return candidato.Prioridad < actual.Prioridad
+}
+
+// CMN: una cola por prioridad, Round Robin dentro de cada cola y desalojo por prioridad entre colas
+type colasMultinivel struct {
+	prioridades
+}
+
+func (colasMultinivel) Encolar(tcb types.TCB) {
+	utils.Encolar_ColaReady(utils.Estado.ColaReady, tcb.Prioridad, tcb)
+}
+
+func (colasMultinivel) Proximo() (types.TCB, bool) {
+	return seleccionarSiguienteHilo()
+}
+
+func (colasMultinivel) Quantum(tcb types.TCB) time.Duration {
+	return time.Duration(utils.Configs.Quantum) * time.Millisecond
+}
+
+func (c colasMultinivel) FinDeQuantum(tcb types.TCB) {
+	c.Encolar(tcb)
+}
+
+func seleccionarSiguienteHilo() (types.TCB, bool) {
+
+	// Encontrar el índice máximo de la cola de ready
+	maxIndex := -1
+	for index := range utils.Estado.ColaReady {
+		if index > maxIndex {
+			maxIndex = index
+		}
+	}
+
+	// Recorremos las colas desde la de mayor prioridad hasta la menor
+	for prioridad := 0; prioridad <= maxIndex; prioridad++ {
+		if len(utils.Estado.ColaReady[prioridad]) > 0 {
+
+			// Tomar el primer hilo de la cola
+			siguienteHilo := utils.Estado.ColaReady[prioridad][0]
+			return siguienteHilo, true
+		}
+	}
+	return types.TCB{}, false // No hay hilos disponibles
+}
@@ -0,0 +1,132 @@
+package planificador
+
+import "github.com/sisoputnfrba/tp-golang/utils/types"
+
+// Árbol AVL que usa CFS para tener los hilos en READY ordenados por vruntime.
+// Insertar, eliminar y buscar el mínimo son O(log n)
+
+// Clave de ordenamiento: vruntime y, ante empate, orden de llegada
+type claveCFS struct {
+	vruntime float64
+	orden    uint64
+}
+
+func (a claveCFS) menor(b claveCFS) bool {
+	if a.vruntime != b.vruntime {
+		return a.vruntime < b.vruntime
+	}
+	return a.orden < b.orden
+}
+
+type nodoCFS struct {
+	clave  claveCFS
+	tcb    types.TCB
+	izq    *nodoCFS
+	der    *nodoCFS
+	altura int
+}
+
+func alturaCFS(n *nodoCFS) int {
+	if n == nil {
+		return 0
+	}
+	return n.altura
+}
+
+func (n *nodoCFS) actualizarAltura() {
+	n.altura = max(alturaCFS(n.izq), alturaCFS(n.der)) + 1
+}
+
+func rotarDerecha(n *nodoCFS) *nodoCFS {
+	raiz := n.izq
+	n.izq = raiz.der
+	raiz.der = n
+	n.actualizarAltura()
+	raiz.actualizarAltura()
+	return raiz
+}
+
+func rotarIzquierda(n *nodoCFS) *nodoCFS {
+	raiz := n.der
+	n.der = raiz.izq
+	raiz.izq = n
+	n.actualizarAltura()
+	raiz.actualizarAltura()
+	return raiz
+}
+
+// Vuelve a balancear el subárbol despues de insertar o eliminar; devuelve la nueva raíz
+func balancearCFS(n *nodoCFS) *nodoCFS {
+	n.actualizarAltura()
+	factor := alturaCFS(n.izq) - alturaCFS(n.der)
+
+	if factor > 1 {
+		if alturaCFS(n.izq.izq) < alturaCFS(n.izq.der) {
+			n.izq = rotarIzquierda(n.izq)
+		}
+		return rotarDerecha(n)
+	}
+	if factor < -1 {
+		if alturaCFS(n.der.der) < alturaCFS(n.der.izq) {
+			n.der = rotarDerecha(n.der)
+		}
+		return rotarIzquierda(n)
+	}
+	return n
+}
+
+func insertarCFS(n *nodoCFS, clave claveCFS, tcb types.TCB) *nodoCFS {
+	if n == nil {
+		return &nodoCFS{clave: clave, tcb: tcb, altura: 1}
+	}
+	if clave.menor(n.clave) {
+		n.izq = insertarCFS(n.izq, clave, tcb)
+	} else {
+		n.der = insertarCFS(n.der, clave, tcb)
+	}
+	return balancearCFS(n)
+}
+
+func eliminarCFS(n *nodoCFS, clave claveCFS) *nodoCFS {
+	if n == nil {
+		return nil
+	}
+	switch {
+	case clave.menor(n.clave):
+		n.izq = eliminarCFS(n.izq, clave)
+	case n.clave.menor(clave):
+		n.der = eliminarCFS(n.der, clave)
+	default:
+		if n.izq == nil {
+			return n.der
+		}
+		if n.der == nil {
+			return n.izq
+		}
+		// Tiene dos hijos: lo reemplazamos por el menor del subárbol derecho
+		sucesor := minimoCFS(n.der)
+		n.clave, n.tcb = sucesor.clave, sucesor.tcb
+		n.der = eliminarCFS(n.der, sucesor.clave)
+	}
+	return balancearCFS(n)
+}
+
+func minimoCFS(n *nodoCFS) *nodoCFS {
+	if n == nil {
+		return nil
+	}
+	for n.izq != nil {
+		n = n.izq
+	}
+	return n
+}
+
+// Recorre el árbol en orden (de menor a mayor vruntime)
+func recorrerCFS(n *nodoCFS, visitar func(tcb types.TCB)) {
+	if n == nil {
+		return
+	}
+	recorrerCFS(n.izq, visitar)
+	visitar(n.tcb)
+	recorrerCFS(n.der, visitar)
+}
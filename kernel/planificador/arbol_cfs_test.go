@@ -0,0 +1,72 @@
+package planificador
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Verifica que la altura guardada en cada nodo sea la real y que el subárbol esté balanceado; devuelve la altura
+func verificarAVL(t *testing.T, n *nodoCFS) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	izq, der := verificarAVL(t, n.izq), verificarAVL(t, n.der)
+	if n.altura != max(izq, der)+1 {
+		t.Fatalf("nodo %v tiene altura %d, se esperaba %d", n.clave, n.altura, max(izq, der)+1)
+	}
+	if izq-der > 1 || der-izq > 1 {
+		t.Fatalf("nodo %v desbalanceado: altura izquierda %d, derecha %d", n.clave, izq, der)
+	}
+	return n.altura
+}
+
+// Inserta y elimina claves al azar (con muchos empates de vruntime) y despues de cada operación
+// el recorrido tiene que salir ordenado y el árbol tiene que seguir siendo AVL
+func TestArbolCFSInsertarYEliminar(t *testing.T) {
+	for _, semilla := range []int64{1, 42, 7} {
+		random := rand.New(rand.NewSource(semilla))
+		var arbol *nodoCFS
+		var claves []claveCFS
+
+		for orden := uint64(0); orden < 500; orden++ {
+			if len(claves) > 0 && random.Intn(3) == 0 {
+				i := random.Intn(len(claves))
+				arbol = eliminarCFS(arbol, claves[i])
+				claves = slices.Delete(claves, i, i+1)
+			} else {
+				clave := claveCFS{vruntime: float64(random.Intn(20)), orden: orden}
+				arbol = insertarCFS(arbol, clave, types.TCB{TID: uint32(orden)})
+				claves = append(claves, clave)
+			}
+
+			ordenadas := slices.Clone(claves)
+			slices.SortFunc(ordenadas, func(a, b claveCFS) int {
+				if a.menor(b) {
+					return -1
+				}
+				if b.menor(a) {
+					return 1
+				}
+				return 0
+			})
+			var recorridas []uint32
+			recorrerCFS(arbol, func(tcb types.TCB) { recorridas = append(recorridas, tcb.TID) })
+			if len(recorridas) != len(ordenadas) {
+				t.Fatalf("semilla %d: el árbol tiene %d hilos, se esperaban %d", semilla, len(recorridas), len(ordenadas))
+			}
+			for i, clave := range ordenadas {
+				if recorridas[i] != uint32(clave.orden) {
+					t.Fatalf("semilla %d: posición %d del recorrido es TID %d, se esperaba %d", semilla, i, recorridas[i], clave.orden)
+				}
+			}
+			if len(ordenadas) > 0 && minimoCFS(arbol).clave != ordenadas[0] {
+				t.Fatalf("semilla %d: minimoCFS devolvió %v, se esperaba %v", semilla, minimoCFS(arbol).clave, ordenadas[0])
+			}
+			verificarAVL(t, arbol)
+		}
+	}
+}
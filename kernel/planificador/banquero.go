@@ -0,0 +1,111 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+)
+
+// -------------------------------------- ALGORITMO DEL BANQUERO --------------------------------------
+
+// Los procesos declaran cuántas instancias de cada recurso pueden llegar a usar como máximo (RESOURCE_DECLARE).
+// Un pedido solo se concede si, suponiendo que se asigna, sigue existiendo un orden en el que todos los procesos
+// pueden obtener su máximo y terminar (estado seguro); sino el hilo queda bloqueado hasta que se liberen recursos.
+// Las instancias totales de cada recurso vienen del config (resources)
+
+// Instancias de cada recurso que no tiene asignadas ningún proceso
+func Recursos_disponibles() map[string]int {
+	disponibles := make(map[string]int)
+	for recurso, total := range utils.Configs.Recursos {
+		disponibles[recurso] = total
+	}
+	for _, pcb := range utils.Estado.MapaPCB {
+		for recurso, asignados := range pcb.Asignados {
+			disponibles[recurso] -= asignados
+		}
+	}
+	return disponibles
+}
+
+// Verifica que exista una secuencia en la que todos los procesos con reclamos puedan terminar
+func Estado_seguro() bool {
+	disponibles := Recursos_disponibles()
+	pendientes := make(map[uint32]bool)
+	for pid, pcb := range utils.Estado.MapaPCB {
+		if len(pcb.Reclamos) > 0 {
+			pendientes[pid] = true
+		}
+	}
+
+	for progreso := true; progreso; {
+		progreso = false
+		for pid := range pendientes {
+			pcb := utils.Estado.MapaPCB[pid]
+			if !puedeTerminar(pcb.Reclamos, pcb.Asignados, disponibles) {
+				continue
+			}
+			// El proceso termina y devuelve todo lo que tenía
+			for recurso, asignados := range pcb.Asignados {
+				disponibles[recurso] += asignados
+			}
+			delete(pendientes, pid)
+			progreso = true
+		}
+	}
+	return len(pendientes) == 0
+}
+
+// Un proceso puede terminar si lo que le falta para llegar a su máximo está disponible
+func puedeTerminar(reclamos map[string]int, asignados map[string]int, disponibles map[string]int) bool {
+	for recurso, maximo := range reclamos {
+		if maximo-asignados[recurso] > disponibles[recurso] {
+			return false
+		}
+	}
+	return true
+}
+
+// Asigna las instancias si hay disponibles y el estado queda seguro; Retorna false (sin asignar nada) si no
+func Conceder_recurso(pid uint32, recurso string, cantidad int) bool {
+	if cantidad > Recursos_disponibles()[recurso] {
+		return false
+	}
+	asignados := utils.Estado.MapaPCB[pid].Asignados
+	asignados[recurso] += cantidad
+	if Estado_seguro() {
+		return true
+	}
+	asignados[recurso] -= cantidad
+	return false
+}
+
+// Cambia el máximo declarado del proceso si el estado sigue siendo seguro; Retorna false (dejando el reclamo anterior) si no.
+// Subir el reclamo teniendo instancias asignadas puede hacer que ya no exista un orden en el que todos terminen
+func Declarar_reclamo(pid uint32, recurso string, cantidad int) bool {
+	reclamos := utils.Estado.MapaPCB[pid].Reclamos
+	anterior, declarado := reclamos[recurso]
+	reclamos[recurso] = cantidad
+	if Estado_seguro() {
+		return true
+	}
+	if declarado {
+		reclamos[recurso] = anterior
+	} else {
+		delete(reclamos, recurso)
+	}
+	return false
+}
+
+// Vuelve a evaluar, por orden de llegada, los pedidos de los hilos bloqueados esperando recursos
+func Reintentar_pedidos_de_recursos(logger *slog.Logger) {
+	for _, bloqueado := range append([]utils.Bloqueado(nil), utils.Estado.ColaBlocked...) {
+		if bloqueado.Motivo != utils.Recurso || utils.Obtener_PCB_por_PID(bloqueado.PID) == nil {
+			continue
+		}
+		if Conceder_recurso(bloqueado.PID, bloqueado.QuienFue, bloqueado.Cantidad) {
+			Desbloquear_hilo(bloqueado.ID)
+			logger.Info(fmt.Sprintf("## (%d:%d) - Desbloqueado por: RECURSO %s (%d instancias)", bloqueado.PID, bloqueado.TID, bloqueado.QuienFue, bloqueado.Cantidad))
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package planificador
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Reclamos y asignados de un proceso, para armar el estado del banquero
+type procesoBanquero struct {
+	reclamos  map[string]int
+	asignados map[string]int
+}
+
+// Arma el estado con 10 instancias de R y los procesos indicados (la clave es el PID)
+func estadoBanquero(procesos map[uint32]procesoBanquero) {
+	utils.Configs = utils.Config{Recursos: map[string]int{"R": 10}}
+	utils.Inicializar_estado()
+	for pid, proceso := range procesos {
+		utils.Estado.MapaPCB[pid] = types.PCB{PID: pid, Reclamos: maps.Clone(proceso.reclamos), Asignados: maps.Clone(proceso.asignados)}
+	}
+}
+
+// El ejemplo clásico: quedan 3 libres y el orden 2, 3, 1 permite que todos lleguen a su máximo
+func seguro() map[uint32]procesoBanquero {
+	return map[uint32]procesoBanquero{
+		1: {map[string]int{"R": 9}, map[string]int{"R": 3}},
+		2: {map[string]int{"R": 4}, map[string]int{"R": 2}},
+		3: {map[string]int{"R": 7}, map[string]int{"R": 2}},
+	}
+}
+
+func TestEstadoSeguro(t *testing.T) {
+	inseguro := seguro()
+	inseguro[1] = procesoBanquero{map[string]int{"R": 9}, map[string]int{"R": 4}}
+
+	casos := []struct {
+		nombre   string
+		procesos map[uint32]procesoBanquero
+		quiere   bool
+	}{
+		{"sin procesos", nil, true},
+		{"todos pueden terminar en algún orden", seguro(), true},
+		{"despues del proceso 2 nadie puede llegar a su máximo", inseguro, false},
+		{"un proceso sin reclamos no cuenta", map[uint32]procesoBanquero{1: {nil, map[string]int{"R": 10}}}, true},
+	}
+	for _, caso := range casos {
+		estadoBanquero(caso.procesos)
+		if obtenido := Estado_seguro(); obtenido != caso.quiere {
+			t.Errorf("%s: Estado_seguro() = %t, se esperaba %t", caso.nombre, obtenido, caso.quiere)
+		}
+	}
+}
+
+func TestConcederRecurso(t *testing.T) {
+	casos := []struct {
+		nombre    string
+		pid       uint32
+		cantidad  int
+		quiere    bool
+		asignados int // Lo que tiene asignado el proceso despues del pedido
+	}{
+		{"deja el estado seguro", 2, 2, true, 4},
+		{"dejaría el estado inseguro", 1, 1, false, 3},
+		{"más de lo disponible", 3, 4, false, 2},
+	}
+	for _, caso := range casos {
+		estadoBanquero(seguro())
+		if obtenido := Conceder_recurso(caso.pid, "R", caso.cantidad); obtenido != caso.quiere {
+			t.Errorf("%s: Conceder_recurso() = %t, se esperaba %t", caso.nombre, obtenido, caso.quiere)
+		}
+		if asignados := utils.Estado.MapaPCB[caso.pid].Asignados["R"]; asignados != caso.asignados {
+			t.Errorf("%s: el proceso quedó con %d asignados, se esperaban %d", caso.nombre, asignados, caso.asignados)
+		}
+	}
+}
+
+func TestDeclararReclamo(t *testing.T) {
+	casos := []struct {
+		nombre    string
+		pid       uint32
+		cantidad  int
+		quiere    bool
+		reclamo   int  // Reclamo del proceso despues de declarar
+		declarado bool // Si el proceso sigue teniendo un reclamo sobre R
+	}{
+		{"bajar el máximo", 1, 5, true, 5, true},
+		{"subirlo deja el estado inseguro y se conserva el anterior", 2, 10, false, 4, true},
+		{"proceso nuevo que puede terminar al final", 4, 10, true, 10, true},
+		{"proceso nuevo que pide más de lo que existe", 4, 11, false, 0, false},
+	}
+	for _, caso := range casos {
+		procesos := seguro()
+		procesos[4] = procesoBanquero{map[string]int{}, map[string]int{}}
+		estadoBanquero(procesos)
+		if obtenido := Declarar_reclamo(caso.pid, "R", caso.cantidad); obtenido != caso.quiere {
+			t.Errorf("%s: Declarar_reclamo() = %t, se esperaba %t", caso.nombre, obtenido, caso.quiere)
+		}
+		reclamo, declarado := utils.Estado.MapaPCB[caso.pid].Reclamos["R"]
+		if reclamo != caso.reclamo || declarado != caso.declarado {
+			t.Errorf("%s: el reclamo quedó en %d (declarado=%t), se esperaba %d (declarado=%t)", caso.nombre, reclamo, declarado, caso.reclamo, caso.declarado)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package planificador
+
+import (
+	"math"
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+func init() {
+	Registrar_algoritmo("CFS", func() Scheduler {
+		return &cfs{claves: make(map[types.PIDTID]claveCFS)}
+	})
+}
+
+// Peso de un hilo con prioridad 0; cada nivel de prioridad pesa 1.25 veces menos que el anterior
+const PESO_BASE = 1024
+
+// Valores que se usan si cfs_target_latency o cfs_min_granularity no están en el config
+const (
+	CFS_LATENCIA_DEFAULT     = 48
+	CFS_GRANULARIDAD_DEFAULT = 6
+)
+
+// CFS: los hilos en READY están en un árbol ordenado por vruntime y siempre se elige el de menor vruntime.
+// El vruntime avanza lo que ejecutó el hilo dividido su peso, asi los de mayor prioridad avanzan más lento.
+// La porción de CPU de cada hilo es la latencia objetivo repartida según los pesos de los que están en READY;
+// al agotarla se lo desaloja con INTERRUPCION_FIN_QUANTUM
+type cfs struct {
+	arbol     *nodoCFS
+	claves    map[types.PIDTID]claveCFS // Clave con la que está cada hilo en el árbol
+	orden     uint64                    // Contador de llegadas para desempatar
+	pesoTotal float64                   // Suma de los pesos de los hilos en READY
+	reloj     relojVirtual
+}
+
+// Mínimo de un reloj virtual (vruntime en CFS y GRUPOS, pase en STRIDE) de los que compiten por la CPU.
+// Lo que llega a READY despues de estar sin competir (un hilo nuevo o que vuelve de estar bloqueado, un proceso
+// que no tenía hilos en READY) arranca desde el mínimo; sino arrancaría muy atrás y acapararía la CPU hasta alcanzar
+// a los demás. El mínimo solo avanza
+type relojVirtual struct {
+	minimo float64
+}
+
+// Valor con el que arranca lo que llega a READY
+func (r *relojVirtual) desde(valor float64) float64 {
+	return max(valor, r.minimo)
+}
+
+// Avanza el mínimo hasta el menor de los valores de los que siguen compitiendo, sin retroceder nunca
+func (r *relojVirtual) avanzar(valor float64, otros ...float64) {
+	for _, otro := range otros {
+		valor = min(valor, otro)
+	}
+	r.minimo = max(r.minimo, valor)
+}
+
+// Peso de un hilo según su prioridad (0 es la mayor)
+func Peso_CFS(tcb types.TCB) float64 {
+	return PESO_BASE / math.Pow(1.25, float64(max(tcb.Prioridad, 0)))
+}
+
+// Los hilos que llegan a READY arrancan desde el vruntime mínimo (ver relojVirtual)
+func (c *cfs) Encolar(tcb types.TCB) {
+	c.Quitar(tcb.PID, tcb.TID)
+
+	if vruntime := c.reloj.desde(tcb.VRuntime); vruntime != tcb.VRuntime {
+		tcb.VRuntime = vruntime
+		utils.Estado.Actualizar_TCB(tcb)
+	}
+
+	c.orden++
+	clave := claveCFS{vruntime: tcb.VRuntime, orden: c.orden}
+	c.arbol = insertarCFS(c.arbol, clave, tcb)
+	c.claves[types.PIDTID{PID: tcb.PID, TID: tcb.TID}] = clave
+	c.pesoTotal += Peso_CFS(tcb)
+}
+
+func (c *cfs) Proximo() (types.TCB, bool) {
+	nodo := minimoCFS(c.arbol)
+	if nodo == nil {
+		return types.TCB{}, false
+	}
+	return nodo.tcb, true
+}
+
+func (c *cfs) Quitar(pid uint32, tid uint32) bool {
+	pidtid := types.PIDTID{PID: pid, TID: tid}
+	clave, existe := c.claves[pidtid]
+	if !existe {
+		return false
+	}
+	for nodo := c.arbol; nodo != nil; {
+		if clave.menor(nodo.clave) {
+			nodo = nodo.izq
+		} else if nodo.clave.menor(clave) {
+			nodo = nodo.der
+		} else {
+			c.pesoTotal -= Peso_CFS(nodo.tcb)
+			break
+		}
+	}
+	c.arbol = eliminarCFS(c.arbol, clave)
+	delete(c.claves, pidtid)
+	return true
+}
+
+// Solo se desaloja cuando el hilo agota su porción de CPU
+func (*cfs) DebeDesalojar(candidato types.TCB, actual types.TCB) bool {
+	return false
+}
+
+// Porción de CPU = periodo * peso del hilo / peso total, con el periodo estirado si hay muchos hilos
+// para que ninguno reciba menos que la granularidad mínima
+func (c *cfs) Quantum(tcb types.TCB) time.Duration {
+	latencia := float64(utils.Configs.CfsLatencia)
+	if latencia <= 0 {
+		latencia = CFS_LATENCIA_DEFAULT
+	}
+	granularidad := float64(utils.Configs.CfsGranularidad)
+	if granularidad <= 0 {
+		granularidad = CFS_GRANULARIDAD_DEFAULT
+	}
+
+	periodo := max(latencia, float64(len(c.claves)+1)*granularidad)
+	peso := Peso_CFS(tcb)
+	porcion := max(periodo*peso/(c.pesoTotal+peso), granularidad)
+
+	return time.Duration(porcion * float64(time.Millisecond))
+}
+
+func (c *cfs) FinDeQuantum(tcb types.TCB) {
+	c.Encolar(tcb)
+}
+
+// Lista de hilos en READY de menor a mayor vruntime
+func (c *cfs) Listos() map[int][]types.TCB {
+	var listos []types.TCB
+	recorrerCFS(c.arbol, func(tcb types.TCB) {
+		listos = append(listos, tcb)
+	})
+	return map[int][]types.TCB{0: listos}
+}
+
+func (c *cfs) FinDeRafaga(tcb *types.TCB, ejecutado float64, desalojado bool) {
+	tcb.VRuntime += ejecutado * PESO_BASE / Peso_CFS(*tcb)
+
+	if nodo := minimoCFS(c.arbol); nodo != nil {
+		c.reloj.avanzar(tcb.VRuntime, nodo.clave.vruntime)
+		return
+	}
+	c.reloj.avanzar(tcb.VRuntime)
+}
@@ -0,0 +1,143 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// -------------------------------------- DETECCIÓN DE DEADLOCKS --------------------------------------
+
+// Grafo de espera: cada hilo bloqueado por un mutex apunta al hilo que lo tiene tomado y cada hilo bloqueado
+// por THREAD_JOIN apunta al hilo que espera que termine. Un hilo solo puede estar bloqueado por una cosa,
+// asi que de cada nodo sale a lo sumo una arista y los ciclos del grafo son disjuntos
+
+// Arista del grafo de espera
+type espera struct {
+	hacia     types.PIDTID
+	bloqueado utils.Bloqueado
+}
+
+// Deadlocks ya informados, para no volver a loguearlos en cada evento si la política es REPORTAR
+var deadlocksReportados = make(map[string]bool)
+
+// Arma el grafo de espera a partir de la cola de bloqueados y los dueños de los mutex
+func Grafo_de_espera() map[types.PIDTID]espera {
+	grafo := make(map[types.PIDTID]espera)
+	for _, bloqueado := range utils.Estado.ColaBlocked {
+		var tid string
+		switch bloqueado.Motivo {
+		case utils.Mutex:
+			tid = utils.Estado.MapaPCB[bloqueado.PID].Mutexs[bloqueado.QuienFue]
+		case utils.THREAD_JOIN:
+			tid = bloqueado.QuienFue
+		default:
+			continue
+		}
+		hacia, err := strconv.ParseUint(tid, 10, 32)
+		if err != nil {
+			continue // El mutex está LIBRE
+		}
+		grafo[types.PIDTID{PID: bloqueado.PID, TID: bloqueado.TID}] = espera{
+			hacia:     types.PIDTID{PID: bloqueado.PID, TID: uint32(hacia)},
+			bloqueado: bloqueado,
+		}
+	}
+	return grafo
+}
+
+// Busca los ciclos del grafo de espera; cada ciclo empieza por su hilo de menor PID y TID
+func Buscar_deadlocks(grafo map[types.PIDTID]espera) [][]types.PIDTID {
+	const (
+		sinVisitar = iota
+		enCamino
+		terminado
+	)
+	visitado := make(map[types.PIDTID]int)
+	var ciclos [][]types.PIDTID
+
+	for inicio := range grafo {
+		var camino []types.PIDTID
+		for nodo := inicio; ; nodo = grafo[nodo].hacia {
+			if visitado[nodo] == enCamino {
+				ciclo := camino[slices.Index(camino, nodo):]
+				ciclos = append(ciclos, rotarAlMenor(ciclo))
+				break
+			}
+			if _, bloqueado := grafo[nodo]; !bloqueado || visitado[nodo] == terminado {
+				break
+			}
+			visitado[nodo] = enCamino
+			camino = append(camino, nodo)
+		}
+		for _, nodo := range camino {
+			visitado[nodo] = terminado
+		}
+	}
+	return ciclos
+}
+
+func rotarAlMenor(ciclo []types.PIDTID) []types.PIDTID {
+	menor := 0
+	for i, nodo := range ciclo {
+		if nodo.PID < ciclo[menor].PID || (nodo.PID == ciclo[menor].PID && nodo.TID < ciclo[menor].TID) {
+			menor = i
+		}
+	}
+	return append(append([]types.PIDTID(nil), ciclo[menor:]...), ciclo[:menor]...)
+}
+
+// Texto del ciclo para el log, por ejemplo "(1:0) espera MUTEX A de (1:1) -> (1:1) espera THREAD_JOIN 0 de (1:0)"
+func Describir_deadlock(grafo map[types.PIDTID]espera, ciclo []types.PIDTID) string {
+	var pasos []string
+	for _, nodo := range ciclo {
+		arista := grafo[nodo]
+		pasos = append(pasos, fmt.Sprintf("(%d:%d) espera %s %s de (%d:%d)", nodo.PID, nodo.TID, arista.bloqueado.Motivo, arista.bloqueado.QuienFue, arista.hacia.PID, arista.hacia.TID))
+	}
+	return strings.Join(pasos, " -> ")
+}
+
+// Busca deadlocks y aplica la política de deadlock_recovery. Se llama dentro del núcleo despues de cada evento
+func Detectar_deadlocks(logger *slog.Logger) {
+	grafo := Grafo_de_espera()
+	vigentes := make(map[string]bool)
+
+	for _, ciclo := range Buscar_deadlocks(grafo) {
+		descripcion := Describir_deadlock(grafo, ciclo)
+		vigentes[descripcion] = true
+		if deadlocksReportados[descripcion] {
+			continue
+		}
+		logger.Info(fmt.Sprintf("## Deadlock detectado: %s", descripcion))
+		Recuperar_deadlock(ciclo, logger)
+	}
+	deadlocksReportados = vigentes
+}
+
+// MATAR_HILO finaliza el hilo más nuevo del ciclo (el de mayor TID), lo que libera sus mutex y a quienes lo esperaban;
+// MATAR_PROCESO finaliza el proceso entero; REPORTAR solo lo deja logueado
+func Recuperar_deadlock(ciclo []types.PIDTID, logger *slog.Logger) {
+	switch utils.Configs.DeadlockRecovery {
+	case "MATAR_HILO":
+		victima := ciclo[0]
+		for _, nodo := range ciclo {
+			if nodo.TID > victima.TID {
+				victima = nodo
+			}
+		}
+		logger.Info(fmt.Sprintf("## (%d:%d) - Finalizado para resolver el deadlock", victima.PID, victima.TID))
+		Finalizar_hilo(victima.TID, victima.PID, utils.FIN_DEADLOCK, logger)
+	case "MATAR_PROCESO":
+		// Todos los hilos del ciclo son del mismo proceso: los mutex y los joins son por proceso
+		if utils.Obtener_PCB_por_PID(ciclo[0].PID) == nil {
+			return
+		}
+		logger.Info(fmt.Sprintf("## Proceso %d finalizado para resolver el deadlock", ciclo[0].PID))
+		Finalizar_proceso(ciclo[0].PID, utils.FIN_DEADLOCK, logger)
+	}
+}
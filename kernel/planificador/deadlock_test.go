@@ -0,0 +1,122 @@
+package planificador
+
+import (
+	"io"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Arma el proceso 1 con los hilos 0, 1 y 2, los mutex con sus dueños y los bloqueos indicados
+func estadoConBloqueos(mutexs map[string]string, bloqueados []utils.Bloqueado) {
+	utils.Inicializar_estado()
+	pcb := types.PCB{PID: 1, TCBs: make(map[uint32]types.TCB), Mutexs: mutexs}
+	for tid := uint32(0); tid < 3; tid++ {
+		pcb.TCBs[tid] = types.TCB{PID: 1, TID: tid}
+	}
+	utils.Estado.MapaPCB[1] = pcb
+	for _, bloqueado := range bloqueados {
+		utils.Estado.Bloquear_hilo(bloqueado)
+	}
+}
+
+func TestBuscarDeadlocks(t *testing.T) {
+	casos := []struct {
+		nombre     string
+		mutexs     map[string]string
+		bloqueados []utils.Bloqueado
+		quiere     [][]types.PIDTID
+	}{
+		{
+			"ciclo de mutex",
+			map[string]string{"A": "0", "B": "1"},
+			[]utils.Bloqueado{
+				{PID: 1, TID: 0, Motivo: utils.Mutex, QuienFue: "B"},
+				{PID: 1, TID: 1, Motivo: utils.Mutex, QuienFue: "A"},
+			},
+			[][]types.PIDTID{{{PID: 1, TID: 0}, {PID: 1, TID: 1}}},
+		},
+		{
+			"THREAD_JOIN mutuo",
+			map[string]string{},
+			[]utils.Bloqueado{
+				{PID: 1, TID: 1, Motivo: utils.THREAD_JOIN, QuienFue: "2"},
+				{PID: 1, TID: 2, Motivo: utils.THREAD_JOIN, QuienFue: "1"},
+			},
+			[][]types.PIDTID{{{PID: 1, TID: 1}, {PID: 1, TID: 2}}},
+		},
+		{
+			"ciclo entre mutex y THREAD_JOIN",
+			map[string]string{"A": "0"},
+			[]utils.Bloqueado{
+				{PID: 1, TID: 0, Motivo: utils.THREAD_JOIN, QuienFue: "2"},
+				{PID: 1, TID: 2, Motivo: utils.Mutex, QuienFue: "A"},
+			},
+			[][]types.PIDTID{{{PID: 1, TID: 0}, {PID: 1, TID: 2}}},
+		},
+		{
+			// 0 espera A de 1, 1 espera que termine 2, y 2 no espera a nadie
+			"cadena sin ciclo",
+			map[string]string{"A": "1"},
+			[]utils.Bloqueado{
+				{PID: 1, TID: 0, Motivo: utils.Mutex, QuienFue: "A"},
+				{PID: 1, TID: 1, Motivo: utils.THREAD_JOIN, QuienFue: "2"},
+			},
+			nil,
+		},
+		{
+			"mutex libre y bloqueos que no son de espera entre hilos",
+			map[string]string{"A": "LIBRE"},
+			[]utils.Bloqueado{
+				{PID: 1, TID: 0, Motivo: utils.Mutex, QuienFue: "A"},
+				{PID: 1, TID: 1, Motivo: utils.Semaforo, QuienFue: "S"},
+				{PID: 1, TID: 2, Motivo: utils.IO, QuienFue: "DISCO"},
+			},
+			nil,
+		},
+	}
+	for _, caso := range casos {
+		estadoConBloqueos(caso.mutexs, caso.bloqueados)
+		ciclos := Buscar_deadlocks(Grafo_de_espera())
+		if !slices.EqualFunc(ciclos, caso.quiere, slices.Equal[[]types.PIDTID]) {
+			t.Errorf("%s: Buscar_deadlocks() = %v, se esperaba %v", caso.nombre, ciclos, caso.quiere)
+		}
+	}
+}
+
+// Con MATAR_HILO se finaliza el hilo de mayor TID del ciclo y el otro se lleva su mutex
+func TestRecuperarDeadlockMatarHilo(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	utils.Configs = utils.Config{DeadlockRecovery: "MATAR_HILO", PoliticaMutex: "FIFO"}
+	estadoConBloqueos(map[string]string{"A": "0", "B": "2"}, []utils.Bloqueado{
+		{PID: 1, TID: 0, Motivo: utils.Mutex, QuienFue: "B"},
+		{PID: 1, TID: 2, Motivo: utils.Mutex, QuienFue: "A"},
+	})
+	Iniciar_planificador(utils.Configs, logger)
+
+	ciclos := Buscar_deadlocks(Grafo_de_espera())
+	if len(ciclos) != 1 {
+		t.Fatalf("se encontraron %d deadlocks, se esperaba uno", len(ciclos))
+	}
+	Recuperar_deadlock(ciclos[0], logger)
+
+	pcb := utils.Estado.MapaPCB[1]
+	if _, existe := pcb.TCBs[2]; existe {
+		t.Fatal("el hilo 2 (el de mayor TID del ciclo) sigue existiendo")
+	}
+	if _, existe := pcb.TCBs[0]; !existe {
+		t.Fatal("se finalizó el hilo 0, que no era la víctima")
+	}
+	if _, existe := pcb.TCBs[1]; !existe {
+		t.Fatal("se finalizó el hilo 1, que no estaba en el ciclo")
+	}
+	if pcb.Mutexs["B"] != "0" {
+		t.Fatalf("el mutex B quedó en %s, se esperaba que se lo lleve el hilo 0", pcb.Mutexs["B"])
+	}
+	if ciclos := Buscar_deadlocks(Grafo_de_espera()); len(ciclos) != 0 {
+		t.Fatalf("despues de recuperar quedan deadlocks: %v", ciclos)
+	}
+}
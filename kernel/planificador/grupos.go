@@ -0,0 +1,123 @@
+package planificador
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+func init() {
+	Registrar_algoritmo("GRUPOS", func() Scheduler {
+		return &grupos{colas: make(map[uint32][]types.TCB), vruntimes: make(map[uint32]float64)}
+	})
+}
+
+// GRUPOS: planificación en dos niveles. Primero se reparte la CPU entre los procesos según sus shares
+// (como los CPU shares de cgroups) y después entre los hilos de cada proceso con Round Robin.
+// Cada proceso tiene un vruntime que avanza lo que ejecutan sus hilos dividido sus shares, y se elige
+// el proceso con menor vruntime que tenga hilos en READY
+type grupos struct {
+	colas     map[uint32][]types.TCB // Hilos en READY de cada proceso, en orden de llegada
+	vruntimes map[uint32]float64     // vruntime de cada proceso, se borra cuando el proceso finaliza
+	reloj     relojVirtual
+}
+
+// Shares de CPU del proceso: los de group_shares si está, sino group_default_share
+func Shares_de_proceso(pid uint32) int {
+	if shares, existe := utils.Configs.GrupoShares[strconv.Itoa(int(pid))]; existe && shares > 0 {
+		return shares
+	}
+	if utils.Configs.GrupoShareDefault > 0 {
+		return utils.Configs.GrupoShareDefault
+	}
+	return PESO_BASE
+}
+
+// Un proceso que no tenía hilos en READY arranca desde el vruntime mínimo (ver relojVirtual)
+func (g *grupos) Encolar(tcb types.TCB) {
+	g.Quitar(tcb.PID, tcb.TID)
+
+	if len(g.colas[tcb.PID]) == 0 && utils.Buscar_Execute_de_proceso(tcb.PID) == nil {
+		g.vruntimes[tcb.PID] = g.reloj.desde(g.vruntimes[tcb.PID])
+	}
+	g.colas[tcb.PID] = append(g.colas[tcb.PID], tcb)
+}
+
+// Procesos con hilos en READY de menor a mayor vruntime; ante igual vruntime desempata por el PID más chico
+func (g *grupos) procesosListos() []uint32 {
+	pids := make([]uint32, 0, len(g.colas))
+	for pid := range g.colas {
+		pids = append(pids, pid)
+	}
+	sort.Slice(pids, func(i, j int) bool {
+		if g.vruntimes[pids[i]] != g.vruntimes[pids[j]] {
+			return g.vruntimes[pids[i]] < g.vruntimes[pids[j]]
+		}
+		return pids[i] < pids[j]
+	})
+	return pids
+}
+
+func (g *grupos) Proximo() (types.TCB, bool) {
+	pids := g.procesosListos()
+	if len(pids) == 0 {
+		return types.TCB{}, false
+	}
+	return g.colas[pids[0]][0], true
+}
+
+func (g *grupos) Quitar(pid uint32, tid uint32) bool {
+	cola := g.colas[pid]
+	for i, tcb := range cola {
+		if tcb.TID == tid {
+			if len(cola) == 1 {
+				delete(g.colas, pid)
+			} else {
+				g.colas[pid] = append(cola[:i:i], cola[i+1:]...)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Solo se desaloja por fin de quantum
+func (*grupos) DebeDesalojar(candidato types.TCB, actual types.TCB) bool {
+	return false
+}
+
+func (*grupos) Quantum(tcb types.TCB) time.Duration {
+	return time.Duration(utils.Configs.Quantum) * time.Millisecond
+}
+
+func (g *grupos) FinDeQuantum(tcb types.TCB) {
+	g.Encolar(tcb)
+}
+
+// Lista de hilos en READY en el orden en que se elegirían sus procesos (de menor a mayor vruntime)
+func (g *grupos) Listos() map[int][]types.TCB {
+	var listos []types.TCB
+	for _, pid := range g.procesosListos() {
+		listos = append(listos, g.colas[pid]...)
+	}
+	return map[int][]types.TCB{0: listos}
+}
+
+func (g *grupos) FinDeRafaga(tcb *types.TCB, ejecutado float64, desalojado bool) {
+	g.vruntimes[tcb.PID] += ejecutado * PESO_BASE / float64(Shares_de_proceso(tcb.PID))
+
+	var listos []float64
+	for pid := range g.colas {
+		listos = append(listos, g.vruntimes[pid])
+	}
+	g.reloj.avanzar(g.vruntimes[tcb.PID], listos...)
+}
+
+// El proceso ya no tiene hilos, no hace falta seguir su vruntime
+func (g *grupos) FinDeProceso(pid uint32) {
+	delete(g.colas, pid)
+	delete(g.vruntimes, pid)
+}
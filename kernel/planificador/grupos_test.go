@@ -0,0 +1,42 @@
+package planificador
+
+import (
+	"testing"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Los hilos en READY salen en un solo nivel, agrupados por proceso de menor a mayor vruntime,
+// y al finalizar un proceso no queda nada suyo en el algoritmo
+func TestGruposListosYFinDeProceso(t *testing.T) {
+	utils.Inicializar_estado()
+	g := algoritmos["GRUPOS"]().(*grupos)
+	g.vruntimes[1] = 30
+	g.vruntimes[2] = 10
+	for _, tcb := range []types.TCB{{PID: 1, TID: 0}, {PID: 2, TID: 0}, {PID: 1, TID: 1}, {PID: 2, TID: 1}} {
+		g.Encolar(tcb)
+	}
+
+	listos := g.Listos()
+	if len(listos) != 1 {
+		t.Fatalf("Listos() devolvió %d niveles, se esperaba uno solo", len(listos))
+	}
+	quiere := []types.PIDTID{{PID: 2, TID: 0}, {PID: 2, TID: 1}, {PID: 1, TID: 0}, {PID: 1, TID: 1}}
+	for i, tcb := range listos[0] {
+		if tcb.PID != quiere[i].PID || tcb.TID != quiere[i].TID {
+			t.Fatalf("posición %d de READY es (%d:%d), se esperaba (%d:%d)", i, tcb.PID, tcb.TID, quiere[i].PID, quiere[i].TID)
+		}
+	}
+	if tcb, _ := g.Proximo(); tcb.PID != 2 {
+		t.Fatalf("Proximo eligió al proceso %d, se esperaba el de menor vruntime", tcb.PID)
+	}
+
+	g.FinDeProceso(2)
+	if _, existe := g.vruntimes[2]; existe {
+		t.Fatal("el vruntime del proceso finalizado sigue guardado")
+	}
+	if _, existe := g.colas[2]; existe {
+		t.Fatal("la cola del proceso finalizado sigue guardada")
+	}
+}
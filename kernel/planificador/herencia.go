@@ -0,0 +1,94 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// -------------------------------------- INVERSIÓN DE PRIORIDADES --------------------------------------
+
+// Con mutex_protocol en HERENCIA el dueño de un mutex ejecuta con la mayor prioridad entre la suya y la de los hilos
+// que esperan sus mutex (y, si esos hilos a su vez tienen mutex, la de quienes los esperan a ellos).
+// Con TECHO, mientras tiene algún mutex ejecuta con la prioridad techo del proceso: la del hilo más prioritario.
+// Al soltar el mutex se vuelve a calcular, asi que recupera su prioridad base cuando ya no tiene nada
+
+// Recalcula la prioridad efectiva de todos los hilos del proceso y reubica en READY a los que cambiaron.
+// Se llama dentro del núcleo cada vez que cambia el dueño de un mutex o quién lo espera
+func Actualizar_prioridades(pid uint32, logger *slog.Logger) {
+	protocolo := utils.Configs.ProtocoloMutex
+	if protocolo != "HERENCIA" && protocolo != "TECHO" {
+		return
+	}
+	pcb, existe := utils.Estado.MapaPCB[pid]
+	if !existe {
+		return
+	}
+
+	for tid, tcb := range pcb.TCBs {
+		efectiva := prioridadEfectiva(pcb, tid, map[uint32]bool{})
+		if efectiva == tcb.Prioridad {
+			continue
+		}
+		logger.Info(fmt.Sprintf("## (%d:%d) - Prioridad efectiva %d (base %d)", pid, tid, efectiva, tcb.PrioridadBase))
+		tcb.Prioridad = efectiva
+		pcb.TCBs[tid] = tcb
+
+		// Si está en READY lo reencolamos para que quede en la posición de su nueva prioridad
+		if Algoritmo.Quitar(pid, tid) {
+			Encolar_Ready(tcb)
+		}
+	}
+}
+
+// Cambia la prioridad base del hilo (nice desde la consola) y lo reubica en READY; si hereda la prioridad de algún mutex
+// se vuelve a calcular la efectiva. Retorna false si el hilo no existe
+func Cambiar_prioridad(pid uint32, tid uint32, prioridad int, logger *slog.Logger) bool {
+	tcb, existe := utils.Estado.MapaPCB[pid].TCBs[tid]
+	if !existe {
+		return false
+	}
+	tcb.PrioridadBase, tcb.Prioridad = prioridad, prioridad
+	utils.Estado.Actualizar_TCB(tcb)
+	if Algoritmo.Quitar(pid, tid) {
+		Encolar_Ready(tcb)
+	}
+	logger.Info(fmt.Sprintf("## (%d:%d) - Nueva prioridad %d", pid, tid, prioridad))
+	Actualizar_prioridades(pid, logger)
+	return true
+}
+
+// Prioridad del hilo según los mutex que tiene; visitados evita recorrer dos veces a un hilo si hay un deadlock
+func prioridadEfectiva(pcb types.PCB, tid uint32, visitados map[uint32]bool) int {
+	visitados[tid] = true
+	efectiva := pcb.TCBs[tid].PrioridadBase
+
+	for mutex, duenio := range pcb.Mutexs {
+		if duenio != strconv.Itoa(int(tid)) {
+			continue
+		}
+		if utils.Configs.ProtocoloMutex == "TECHO" {
+			return min(efectiva, Techo_de_prioridad(pcb))
+		}
+		for _, bloqueado := range utils.Bloqueados_por(utils.Estado.ColaBlocked, pcb.PID, utils.Mutex, mutex) {
+			if _, existe := pcb.TCBs[bloqueado.TID]; existe && !visitados[bloqueado.TID] {
+				efectiva = min(efectiva, prioridadEfectiva(pcb, bloqueado.TID, visitados))
+			}
+		}
+	}
+	return efectiva
+}
+
+// Prioridad techo de los mutex del proceso: la del hilo más prioritario (0 es la mayor)
+func Techo_de_prioridad(pcb types.PCB) int {
+	techo := -1
+	for _, tcb := range pcb.TCBs {
+		if techo == -1 || tcb.PrioridadBase < techo {
+			techo = tcb.PrioridadBase
+		}
+	}
+	return techo
+}
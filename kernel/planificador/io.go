@@ -0,0 +1,62 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+)
+
+// Busca el dispositivo de IO por nombre; sin nombre devuelve el primero del config
+func Buscar_dispositivo(nombre string) (utils.DispositivoIO, bool) {
+	if nombre == "" {
+		return utils.Configs.DispositivosIO[0], true
+	}
+	for _, dispositivo := range utils.Configs.DispositivosIO {
+		if dispositivo.Nombre == nombre {
+			return dispositivo, true
+		}
+	}
+	return utils.DispositivoIO{}, false
+}
+
+// Encola la solicitud en su dispositivo (con el ID del bloqueo del hilo) y la empieza a atender si tiene lugar (se llama dentro de un evento del núcleo)
+func Solicitar_IO(solicitud utils.SolicitudIO, logger *slog.Logger) {
+	utils.Encolar_solicitud_IO(solicitud)
+	Iniciar_IO(solicitud.Dispositivo, logger)
+}
+
+// Empieza solicitudes del dispositivo mientras tenga lugar libre; al terminar cada una el hilo vuelve a READY
+func Iniciar_IO(nombre string, logger *slog.Logger) {
+	dispositivo, existe := Buscar_dispositivo(nombre)
+	if !existe {
+		return
+	}
+
+	for utils.Estado.IOEnCurso[nombre] < dispositivo.Concurrencia {
+		cola := utils.Estado.ColasIO[nombre]
+		solicitud, haySolicitudes := utils.Proxima_solicitud(&cola, dispositivo.Politica)
+		utils.Estado.ColasIO[nombre] = cola
+		if !haySolicitudes {
+			return
+		}
+		utils.Estado.IOEnCurso[nombre]++
+
+		// Simular la duración de la E/S
+		logger.Info(fmt.Sprintf("Procesando E/S en %s para TID %d durante %d ms", nombre, solicitud.TID, solicitud.Duracion))
+		time.AfterFunc(time.Duration(solicitud.Duracion)*time.Millisecond, func() {
+			Notificar(EventosReady, func() {
+				utils.Estado.IOEnCurso[nombre]--
+
+				// Una vez terminada la E/S, desbloquear el hilo que la pidió (si no lo finalizaron mientras tanto)
+				if Desbloquear_hilo(solicitud.ID) {
+					logger.Info(fmt.Sprintf("## (%d:%d) finalizó IO y pasa a READY", solicitud.PID, solicitud.TID))
+				} else {
+					logger.Info(fmt.Sprintf("## (%d:%d) finalizó la solicitud de IO %d pero el hilo ya no está esperando", solicitud.PID, solicitud.TID, solicitud.ID))
+				}
+				Iniciar_IO(nombre, logger)
+			})
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// -------------------------------------- JERARQUÍA DE PROCESOS --------------------------------------
+
+// Cada proceso recuerda a su padre (el que hizo PROCESS_CREATE). Cuando finaliza queda como zombie con su estado de
+// salida hasta que el padre lo espera con PROCESS_WAIT; si el padre ya no existe no se guarda. Sus hijos vivos quedan
+// huérfanos y los adopta init, y los zombies que no esperó se descartan.
+// Todas se llaman dentro de un evento del núcleo
+
+// Proceso que adopta a los huérfanos: el que crea el kernel al iniciar con los argumentos de la línea de comandos.
+// Queda en 0 si el kernel arrancó sin proceso inicial; los que se crean despues (por ejemplo con run en la consola) no son init
+var PIDInit uint32
+
+// Deja al proceso como zombie para su padre (despertando a un hilo del padre que lo esté esperando) y reparte sus hijos
+func Registrar_fin_de_proceso(pcb types.PCB, estado int, logger *slog.Logger) {
+	for pid, hijo := range utils.Estado.MapaPCB {
+		if hijo.Padre == pcb.PID {
+			hijo.Padre = padreAdoptivo(pcb.PID)
+			utils.Estado.MapaPCB[pid] = hijo
+			logger.Info(fmt.Sprintf("## (%d) - Proceso huérfano adoptado por el proceso %d", pid, hijo.Padre))
+		}
+	}
+	for pid, zombie := range utils.Estado.Zombies {
+		if zombie.Padre == pcb.PID {
+			delete(utils.Estado.Zombies, pid)
+		}
+	}
+
+	if _, existe := utils.Estado.MapaPCB[pcb.Padre]; !existe {
+		return
+	}
+	utils.Estado.Zombies[pcb.PID] = utils.Zombie{PID: pcb.PID, Padre: pcb.Padre, Estado: estado}
+	logger.Info(fmt.Sprintf("## (%d) - Proceso zombie con estado de salida %d, esperando al proceso %d", pcb.PID, estado, pcb.Padre))
+
+	if bloqueado, existe := utils.Primer_bloqueado(utils.Estado.ColaBlocked, pcb.Padre, utils.Espera, strconv.Itoa(int(pcb.PID))); existe {
+		Desbloquear_hilo(bloqueado.ID)
+		logger.Info(fmt.Sprintf("## (%d:%d) - Desbloqueado por: PROCESS_WAIT %d", bloqueado.PID, bloqueado.TID, pcb.PID))
+	}
+}
+
+// Los huérfanos pasan a init; si el que finaliza es init (o no hay init) quedan sin padre
+func padreAdoptivo(finalizado uint32) uint32 {
+	if _, existe := utils.Estado.MapaPCB[PIDInit]; !existe || PIDInit == 0 || finalizado == PIDInit {
+		return 0
+	}
+	return PIDInit
+}
+
+// Indica si hijo es un proceso vivo creado (o adoptado) por padre
+func Es_hijo(padre uint32, hijo uint32) bool {
+	pcb, existe := utils.Estado.MapaPCB[hijo]
+	return existe && pcb.Padre == padre
+}
+
+// Si hijo es un zombie de padre lo saca de los zombies y lo devuelve; el bool es false si no lo es
+func Cosechar_hijo(padre uint32, hijo uint32, logger *slog.Logger) (utils.Zombie, bool) {
+	zombie, existe := utils.Estado.Zombies[hijo]
+	if !existe || zombie.Padre != padre {
+		return utils.Zombie{}, false
+	}
+	delete(utils.Estado.Zombies, hijo)
+	logger.Info(fmt.Sprintf("## (%d) - El proceso %d recibe el estado de salida %d de su hijo", padre, hijo, zombie.Estado))
+	return zombie, true
+}
@@ -0,0 +1,133 @@
+package planificador
+
+import (
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+func init() {
+	Registrar_algoritmo("LOTERIA", func() Scheduler { return &loteria{} })
+	Registrar_algoritmo("STRIDE", func() Scheduler { return &stride{} })
+}
+
+// Constante de la que se divide la cantidad de tickets para obtener el stride de cada hilo
+const STRIDE_BASE = 10000
+
+// Tickets del hilo: los que se le asignaron con SET_TICKETS o, si no tiene, los que le corresponden por prioridad
+// (prioridad 0 = 100 tickets, 1 = 50, 2 = 33, ...)
+func Tickets(tcb types.TCB) int {
+	if tcb.Tickets > 0 {
+		return tcb.Tickets
+	}
+	return max(100/(max(tcb.Prioridad, 0)+1), 1)
+}
+
+// LOTERIA: una sola cola (nivel 0), en cada despacho se sortea un ticket entre todos los hilos en READY.
+// Usa quantum para que el reparto de CPU sea proporcional a los tickets.
+// El sorteo se hace solo cuando hay una CPU libre (Sortear), Proximo únicamente consulta el resultado:
+// asi la secuencia que sale de la semilla depende de los despachos y no de cuántas veces se consulta
+type loteria struct {
+	fifo
+	ganador *types.PIDTID // Hilo que ganó el último sorteo, hasta que se despacha
+}
+
+// Devuelve el ganador del último sorteo si sigue en READY; si no hay sorteo pendiente devuelve el primero de la cola sin sortear
+func (l *loteria) Proximo() (types.TCB, bool) {
+	cola := utils.Estado.ColaReady[0]
+	if len(cola) == 0 {
+		return types.TCB{}, false
+	}
+	if l.ganador != nil {
+		for _, tcb := range cola {
+			if tcb.PID == l.ganador.PID && tcb.TID == l.ganador.TID {
+				return tcb, true
+			}
+		}
+	}
+	return cola[0], true
+}
+
+// Sortea un ticket entre todos los hilos en READY; el ganador es el que devuelve Proximo hasta que se despacha
+func (l *loteria) Sortear() {
+	l.ganador = nil
+	cola := utils.Estado.ColaReady[0]
+	if len(cola) == 0 {
+		return
+	}
+
+	total := 0
+	for _, tcb := range cola {
+		total += Tickets(tcb)
+	}
+
+	// Recorremos la cola hasta llegar al hilo que tiene el ticket ganador
+	ticket := utils.Random.Intn(total)
+	for _, tcb := range cola {
+		ticket -= Tickets(tcb)
+		if ticket < 0 {
+			l.ganador = &types.PIDTID{PID: tcb.PID, TID: tcb.TID}
+			return
+		}
+	}
+}
+
+func (l *loteria) Quitar(pid uint32, tid uint32) bool {
+	if l.ganador != nil && l.ganador.PID == pid && l.ganador.TID == tid {
+		l.ganador = nil
+	}
+	return l.fifo.Quitar(pid, tid)
+}
+
+func (*loteria) Quantum(tcb types.TCB) time.Duration {
+	return time.Duration(utils.Configs.Quantum) * time.Millisecond
+}
+
+// STRIDE: una sola cola (nivel 0), se elige el hilo con menor pase y cada vez que deja la CPU su pase avanza
+// STRIDE_BASE / tickets por cada quantum que ejecutó, asi los que tienen mas tickets vuelven a ser elegidos antes
+// y un hilo que se bloquea enseguida paga solo la parte del quantum que usó
+type stride struct {
+	fifo
+	reloj relojVirtual // Pase global: el del ultimo hilo que ejecutó
+}
+
+// Los hilos que llegan a READY arrancan desde el pase global (ver relojVirtual)
+func (s *stride) Encolar(tcb types.TCB) {
+	if pase := s.reloj.desde(tcb.Pase); pase != tcb.Pase {
+		tcb.Pase = pase
+		utils.Estado.Actualizar_TCB(tcb)
+	}
+	utils.Encolar_ColaReady(utils.Estado.ColaReady, 0, tcb)
+}
+
+func (s *stride) FinDeQuantum(tcb types.TCB) {
+	s.Encolar(tcb)
+}
+
+func (*stride) Proximo() (types.TCB, bool) {
+	if len(utils.Estado.ColaReady[0]) == 0 {
+		return types.TCB{}, false
+	}
+	siguienteHilo := utils.Estado.ColaReady[0][0]
+	// Ante igual pase desempata por el primero que llegó
+	for _, tcb := range utils.Estado.ColaReady[0] {
+		if tcb.Pase < siguienteHilo.Pase {
+			siguienteHilo = tcb
+		}
+	}
+	return siguienteHilo, true
+}
+
+func (*stride) Quantum(tcb types.TCB) time.Duration {
+	return time.Duration(utils.Configs.Quantum) * time.Millisecond
+}
+
+func (s *stride) FinDeRafaga(tcb *types.TCB, ejecutado float64, desalojado bool) {
+	s.reloj.avanzar(tcb.Pase)
+	paso := float64(STRIDE_BASE) / float64(Tickets(*tcb))
+	if utils.Configs.Quantum > 0 {
+		paso *= ejecutado / float64(utils.Configs.Quantum)
+	}
+	tcb.Pase += paso
+}
@@ -0,0 +1,87 @@
+package planificador
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+func TestTickets(t *testing.T) {
+	casos := []struct {
+		nombre string
+		tcb    types.TCB
+		quiere int
+	}{
+		{"asignados con SET_TICKETS", types.TCB{Tickets: 30, Prioridad: 0}, 30},
+		{"prioridad 0", types.TCB{Prioridad: 0}, 100},
+		{"prioridad 1", types.TCB{Prioridad: 1}, 50},
+		{"prioridad 2", types.TCB{Prioridad: 2}, 33},
+		{"prioridad negativa cuenta como 0", types.TCB{Prioridad: -3}, 100},
+		{"prioridad muy baja tiene al menos 1", types.TCB{Prioridad: 500}, 1},
+	}
+	for _, caso := range casos {
+		if obtenidos := Tickets(caso.tcb); obtenidos != caso.quiere {
+			t.Errorf("%s: Tickets() = %d, se esperaba %d", caso.nombre, obtenidos, caso.quiere)
+		}
+	}
+}
+
+// Con la misma semilla y la misma cola los sorteos tienen que salir siempre iguales
+func TestLoteriaSorteosConSemilla(t *testing.T) {
+	casos := []struct {
+		nombre  string
+		semilla int64
+		tickets []int // Tickets de cada hilo, el TID es la posición (y la prioridad, para los que no tienen)
+		quiere  []uint32
+	}{
+		{"semilla 1", 1, []int{100, 50, 10}, []uint32{0, 0, 0, 0, 0, 0, 0, 1, 1, 1}},
+		{"semilla 42", 42, []int{100, 50, 10}, []uint32{1, 1, 0, 0, 0, 0, 0, 1, 0, 0}},
+		{"tickets por prioridad", 7, []int{0, 0, 0}, []uint32{0, 1, 2, 1, 1, 2, 0, 2, 0, 1}},
+	}
+	for _, caso := range casos {
+		utils.Inicializar_estado()
+		utils.Random = rand.New(rand.NewSource(caso.semilla))
+		l := &loteria{}
+		for tid, tickets := range caso.tickets {
+			l.Encolar(types.TCB{PID: 1, TID: uint32(tid), Tickets: tickets, Prioridad: tid})
+		}
+
+		for i, quiere := range caso.quiere {
+			l.Sortear()
+			// Consultar varias veces no vuelve a sortear ni avanza la semilla
+			for j := 0; j < 3; j++ {
+				if tcb, hay := l.Proximo(); !hay || tcb.TID != quiere {
+					t.Fatalf("%s: sorteo %d devolvió TID %d (hay=%t), se esperaba %d", caso.nombre, i, tcb.TID, hay, quiere)
+				}
+			}
+		}
+	}
+}
+
+// Sin sorteo pendiente Proximo devuelve el primero de la cola, y al despachar al ganador el sorteo se descarta
+func TestLoteriaProximoSinSorteo(t *testing.T) {
+	utils.Inicializar_estado()
+	utils.Random = rand.New(rand.NewSource(42))
+	l := &loteria{}
+	if _, hay := l.Proximo(); hay {
+		t.Fatal("Proximo con la cola vacía devolvió un hilo")
+	}
+	for tid := uint32(0); tid < 3; tid++ {
+		l.Encolar(types.TCB{PID: 1, TID: tid, Tickets: 10})
+	}
+	if tcb, _ := l.Proximo(); tcb.TID != 0 {
+		t.Fatalf("sin sorteo Proximo devolvió TID %d, se esperaba el primero de la cola", tcb.TID)
+	}
+
+	l.Sortear()
+	ganador, _ := l.Proximo()
+	l.Quitar(ganador.PID, ganador.TID)
+	if l.ganador != nil {
+		t.Fatal("el sorteo sigue pendiente despues de sacar al ganador de READY")
+	}
+	if tcb, _ := l.Proximo(); tcb.TID == ganador.TID {
+		t.Fatalf("Proximo devolvió al ganador TID %d que ya no está en READY", tcb.TID)
+	}
+}
@@ -0,0 +1,24 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+)
+
+// -------------------------------------- COLAS DE MENSAJES --------------------------------------
+
+// Las colas de mensajes son del kernel y las comparten todos los procesos. Un hilo que se bloquea en MQ_SEND o MQ_RECV
+// queda con la PC en esa instrucción, asi que al despertarlo la vuelve a ejecutar: si otro hilo se le adelantó
+// (tomó el mensaje o llenó la cola) se vuelve a bloquear
+
+// Despierta al primer hilo bloqueado en la cola por ese motivo: MQVacia cuando llega un mensaje, MQLlena cuando se saca uno
+func Despertar_de_cola_de_mensajes(cola string, motivo utils.Motivo, logger *slog.Logger) {
+	bloqueado, existe := utils.Primer_bloqueado_global(utils.Estado.ColaBlocked, motivo, cola)
+	if !existe {
+		return
+	}
+	Desbloquear_hilo(bloqueado.ID)
+	logger.Info(fmt.Sprintf("## (%d:%d) - Desbloqueado por: %s %s", bloqueado.PID, bloqueado.TID, motivo, cola))
+}
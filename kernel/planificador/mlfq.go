@@ -0,0 +1,97 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+func init() {
+	Registrar_algoritmo("MLFQ", func() Scheduler { return mlfq{} })
+}
+
+// MLFQ: como CMN pero el nivel de cada hilo (TCB.Nivel) cambia con su comportamiento.
+// Todos arrancan en el nivel 0; si agota el quantum baja un nivel, si se bloquea antes se queda
+// (o sube si mlfq_promote_on_block está activo) y cada mlfq_boost_interval ms todos vuelven al nivel 0.
+type mlfq struct {
+	colasMultinivel
+}
+
+func (mlfq) Encolar(tcb types.TCB) {
+	utils.Encolar_ColaReady(utils.Estado.ColaReady, tcb.Nivel, tcb)
+}
+
+func (mlfq) DebeDesalojar(candidato types.TCB, actual types.TCB) bool {
+	return candidato.Nivel < actual.Nivel
+}
+
+func (mlfq) Quantum(tcb types.TCB) time.Duration {
+	quantums := Quantums_MLFQ()
+	nivel := min(tcb.Nivel, len(quantums)-1)
+	return time.Duration(quantums[nivel]) * time.Millisecond
+}
+
+// Agotó el quantum: baja un nivel (si no está en el ultimo)
+func (m mlfq) FinDeQuantum(tcb types.TCB) {
+	if tcb.Nivel < len(Quantums_MLFQ())-1 {
+		tcb.Nivel++
+		utils.Estado.Actualizar_TCB(tcb)
+	}
+	m.Encolar(tcb)
+}
+
+// Dejó la CPU antes de agotar el quantum: se queda en su nivel o sube uno
+func (mlfq) FinDeRafaga(tcb *types.TCB, ejecutado float64, desalojado bool) {
+	if !desalojado && utils.Configs.MlfqPromover && tcb.Nivel > 0 {
+		tcb.Nivel--
+	}
+}
+
+func (mlfq) Iniciar(logger *slog.Logger) {
+	if utils.Configs.MlfqBoost > 0 {
+		go Boost_MLFQ(time.Duration(utils.Configs.MlfqBoost)*time.Millisecond, logger)
+	}
+}
+
+// Quantum de cada nivel; si no están en el config se usan 3 niveles a partir del quantum (q, 2q, 4q)
+func Quantums_MLFQ() []int {
+	if len(utils.Configs.MlfqQuantums) > 0 {
+		return utils.Configs.MlfqQuantums
+	}
+	q := utils.Configs.Quantum
+	return []int{q, 2 * q, 4 * q}
+}
+
+// Cada cierto intervalo sube todos los hilos al nivel 0 para que ninguno quede en inanición
+func Boost_MLFQ(intervalo time.Duration, logger *slog.Logger) {
+	ticker := time.NewTicker(intervalo)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		Notificar(EventosReady, func() {
+			// Los que están en READY pasan al nivel 0 respetando el orden de los niveles
+			var boosteados []types.TCB
+			for nivel := 0; nivel < len(Quantums_MLFQ()); nivel++ {
+				for _, tcb := range utils.Estado.ColaReady[nivel] {
+					tcb.Nivel = 0
+					boosteados = append(boosteados, tcb)
+				}
+				delete(utils.Estado.ColaReady, nivel)
+			}
+			utils.Estado.ColaReady[0] = boosteados
+
+			// Los que están ejecutando o bloqueados vuelven al nivel 0 cuando se los encole
+			for pid, pcb := range utils.Estado.MapaPCB {
+				for tid, tcb := range pcb.TCBs {
+					tcb.Nivel = 0
+					utils.Estado.MapaPCB[pid].TCBs[tid] = tcb
+				}
+			}
+
+			logger.Info(fmt.Sprintf("## Boost de prioridad MLFQ: %d hilos en READY vuelven al nivel 0", len(boosteados)))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package planificador
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/client"
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// -------------------------------------- NÚCLEO DEL KERNEL --------------------------------------
+
+// El estado del kernel (colas, mapa de PCBs y CPUs en ejecución) lo modifica una sola goroutine, el núcleo.
+// Los handlers HTTP y los timers no tocan las colas: le mandan un evento con el cambio a hacer y esperan a que
+// el núcleo lo procese. Despues de cada evento el núcleo despacha a las CPUs libres, asi no hace falta ningún
+// semáforo ni ciclo que espere con sleep. Como los hilos solo se bloquean dentro de un evento, antes de planificar
+// también se entregan las señales pendientes y se buscan deadlocks.
+
+// Evento que procesa el núcleo; Aplicar se ejecuta dentro de la goroutine del núcleo
+type Evento struct {
+	Aplicar func()
+	hecho   chan struct{}
+}
+
+// Canales por los que llegan los eventos al núcleo, separados según lo que le pasa al hilo
+var (
+	EventosReady        = make(chan Evento) // Un hilo pasa a READY: creación, fin de IO, desbloqueo, boost
+	EventosBloqueo      = make(chan Evento) // El hilo que está ejecutando se bloquea
+	EventosExit         = make(chan Evento) // Finaliza un hilo o un proceso
+	EventosInterrupcion = make(chan Evento) // Fin de quantum, desalojo por prioridad, segmentation fault
+	EventosCompactacion = make(chan Evento) // Memoria terminó de compactar
+	EventosSyscall      = make(chan Evento) // Syscalls y consultas que no cambian el estado de ningún hilo
+	EventosConsulta     = make(chan Evento) // Consultas de solo lectura (administración, consola): despues no se planifica
+)
+
+// Manda el evento al núcleo y espera a que lo procese. No se puede llamar desde dentro de un evento
+func Notificar(canal chan Evento, aplicar func()) {
+	hecho := make(chan struct{})
+	canal <- Evento{Aplicar: aplicar, hecho: hecho}
+	<-hecho
+}
+
+// Lee el estado dentro del núcleo sin que despues se entreguen señales, se busquen deadlocks ni se planifique,
+// asi una consulta no despacha hilos ni avanza los sorteos. leer no puede modificar el estado
+func Consultar(leer func()) {
+	Notificar(EventosConsulta, leer)
+}
+
+// Goroutine del núcleo: procesa los eventos de a uno y despues de cada uno (salvo las consultas) planifica
+func Nucleo(logger *slog.Logger) {
+	for {
+		var evento Evento
+		select {
+		case evento = <-EventosConsulta:
+			evento.Aplicar()
+			close(evento.hecho)
+			continue
+		case evento = <-EventosReady:
+		case evento = <-EventosBloqueo:
+		case evento = <-EventosExit:
+		case evento = <-EventosInterrupcion:
+		case evento = <-EventosCompactacion:
+		case evento = <-EventosSyscall:
+		}
+
+		evento.Aplicar()
+		Entregar_seniales(logger)
+		Detectar_deadlocks(logger)
+		Planificar(logger)
+		close(evento.hecho)
+	}
+}
+
+// Se pide compactar cuando no hay ningún hilo ejecutando; mientras tanto no se despacha a nadie
+func Compactar_si_corresponde(logger *slog.Logger) {
+	if !NecesitoCompactar || compactando || utils.Hay_hilos_ejecutando() {
+		return
+	}
+	compactando = true
+	go Compactar(logger)
+}
+
+var compactando bool
+
+// Pide a memoria que compacte y avisa al núcleo cuando terminó
+func Compactar(logger *slog.Logger) {
+	exito := client.Enviar_Body(types.EstructuraEmpty{}, utils.Configs.IpMemory, utils.Configs.PortMemory, "compactar", logger)
+
+	Notificar(EventosCompactacion, func() {
+		compactando = false
+		NecesitoCompactar = false
+		if !exito {
+			logger.Error("Error al compactar la memoria")
+			return
+		}
+		logger.Info("Compactacion de Memoria exitosa, reintentando inicializar proceso")
+		Reintentar_procesos(logger)
+	})
+}
+
+// Arranca el timer del quantum; al vencer se desaloja al hilo si sigue siendo la misma ejecución
+func Iniciar_quantum(exec *utils.ExecuteActual, quantum time.Duration, logger *slog.Logger) {
+	time.AfterFunc(quantum, func() {
+		Notificar(EventosInterrupcion, func() {
+			if actual := utils.Estado.Executes[exec.CPU]; actual != nil && actual.IDexecute == exec.IDexecute {
+				Enviar_interrupcion(exec, "FIN_QUANTUM", "INTERRUPCION_FIN_QUANTUM", logger)
+			}
+		})
+	})
+}
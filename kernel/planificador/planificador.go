@@ -3,8 +3,6 @@ package planificador
 import (
 	"fmt"
 	"log/slog"
-	"sync"
-	"time"
 
 	"github.com/sisoputnfrba/tp-golang/kernel/client"
 	"github.com/sisoputnfrba/tp-golang/kernel/utils"
@@ -12,69 +10,41 @@ import (
 	"github.com/sisoputnfrba/tp-golang/utils/types"
 )
 
-var ColaNew []types.ProcesoNew    //Cola de procesos nuevos (Manejada por FIFO)
-var ColaReady map[int][]types.TCB // Aca tengo dudas de como es, no me queda claro si las colas son distintas para PCB y TCB
-var ColaBlocked []utils.Bloqueado
-var ColaExit []types.TCB //Cola de procesos finalizados
-var ColaIO []utils.SolicitudIO
-var MapColasMultinivel map[int][]types.TCB
-
-var Semaforo *utils.Semaphore
-
-// Variables para el tema de los quantums
-var (
-	Mu              sync.Mutex
-	ExecuteContador int
-)
+// Contador de ejecuciones, para que el timer del quantum sepa si el hilo sigue en la misma ejecución
+var ExecuteContador int
 
+// Memoria pidió compactar: no se despacha a nadie hasta que se vacíen las CPUs y termine la compactación
 var NecesitoCompactar bool
 
+// Las colas de estados y el mapa de PCBs están en utils.Estado, que solo se modifica desde el núcleo
 func Inicializar_colas() {
-	ColaNew = []types.ProcesoNew{}
-	ColaReady = make(map[int][]types.TCB)
-	ColaBlocked = []utils.Bloqueado{}
-	ColaExit = []types.TCB{}
-	ColaIO = []utils.SolicitudIO{}
-	MapColasMultinivel = make(map[int][]types.TCB)
-	Semaforo = utils.NewSemaphore(1)
-	utils.Execute = nil
+	utils.Inicializar_estado()
 }
 
-// Se le pasa el archivo de pseudocódigo, el tamaño del proceso y la prioridad
-func Crear_proceso(pseudo string, tamanio int, prioridad int, logger *slog.Logger) {
-	pcb := generadores.Generar_PCB()
-	utils.MapaPCB[pcb.PID] = pcb // Guardo el PCB en el mapa de PCBs
+// Se le pasa el archivo de pseudocódigo, el tamaño del proceso, la prioridad y el PID del padre (0 si lo crea el kernel).
+// Devuelve el PID del proceso creado (se llama dentro de un evento del núcleo)
+func Crear_proceso(pseudo string, tamanio int, prioridad int, padre uint32, logger *slog.Logger) uint32 {
+	pcb := generadores.Generar_PCB(padre)
+	utils.Estado.MapaPCB[pcb.PID] = pcb // Guardo el PCB en el mapa de PCBs
 	logger.Info(fmt.Sprintf("## (%d:0) Se crea el proceso - Estado: NEW", pcb.PID))
-	if len(ColaNew) == 0 {
+	if len(utils.Estado.ColaNew) == 0 {
 		// Enviar a memoria el archivo de pseudocódigo y el tamaño del proceso
 		success, alt := Inicializar_proceso(pcb, pseudo, tamanio, prioridad, logger)
 		if !success {
-			// Si no se pudo incializar el proceso y necesita compactacion
+			// Si necesita compactacion el núcleo la pide cuando se vacíen las CPUs y despues lo reintenta desde ColaNew
 			if alt == "COMPACTACION" {
-
 				NecesitoCompactar = true
-				for utils.Execute != nil {
-					time.Sleep(1000 * time.Millisecond) //no me parece la mejor implementacion a nivel recursos pero no se me ocurre otra sin modificar mucho la estructura actual
-				}
-				if client.Enviar_QueryPath(0, utils.Configs.IpMemory, utils.Configs.PortMemory, "compactar", "PATCH", logger) {
-					logger.Info("Compactacion de Memoria exitosa, reintentando inicializar proceso")
-					Inicializar_proceso(pcb, pseudo, tamanio, prioridad, logger)
-
-					NecesitoCompactar = false
-					SignalEnviado = true
-					Semaforo.Signal()
-				}
-
 			}
 			// Si no se pudo inicializar el proceso, se encola en ColaNew
 			new := types.ProcesoNew{PCB: pcb, Pseudo: pseudo, Tamanio: tamanio, Prioridad: prioridad}
-			utils.Encolar(&ColaNew, new)
+			utils.Encolar(&utils.Estado.ColaNew, new)
 		}
 	} else {
 		// Si ya hay otros procesos esperando, simplemente encolar
 		new := types.ProcesoNew{PCB: pcb, Pseudo: pseudo, Tamanio: tamanio, Prioridad: prioridad}
-		utils.Encolar(&ColaNew, new)
+		utils.Encolar(&utils.Estado.ColaNew, new)
 	}
+	return pcb.PID
 }
 
 // Devuelve un booleano y un string, este indica en caso de que no se pueda inicializar el proceso, si necesita compactacion
@@ -86,13 +56,9 @@ func Inicializar_proceso(pcb types.PCB, pseudo string, tamanio int, prioridad in
 	if success {
 		// Si se asigna espacio, se crea el TCB 0 y se pasa a READY
 		tcb := generadores.Generar_TCB(&pcb, prioridad)
-		utils.MapaPCB[pcb.PID] = pcb // Actualizo el PCB en el mapa de PCBs (nose si está bien asi o abria que agregar unicamente el tcb y no sobreescribir)
-		utils.Encolar_ColaReady(ColaReady, tcb)
+		utils.Estado.MapaPCB[pcb.PID] = pcb // Actualizo el PCB en el mapa de PCBs (nose si está bien asi o abria que agregar unicamente el tcb y no sobreescribir)
+		Encolar_Ready(tcb)
 		logger.Info(fmt.Sprintf("## (%d:%d) Se crea el Hilo - Estado: READY", pcb.PID, tcb.TID))
-
-		// Desbloquear el planificador para procesar el hilo en READY
-		SignalEnviado = true
-		Semaforo.Signal()
 		return true, ""
 	}
 	if alt == "COMPACTACION" {
@@ -109,44 +75,45 @@ func Inicializar_proceso(pcb types.PCB, pseudo string, tamanio int, prioridad in
 }
 
 func Reintentar_procesos(logger *slog.Logger) {
-	if len(ColaNew) > 0 {
+	if len(utils.Estado.ColaNew) > 0 {
 		// Intentar inicializar el primer proceso en ColaNew
-		new := ColaNew[0]
+		new := utils.Estado.ColaNew[0]
 		success, alt := Inicializar_proceso(new.PCB, new.Pseudo, new.Tamanio, new.Prioridad, logger)
 		if success {
 			// Si se inicializa correctamente, quitarlo de ColaNew
-			utils.Desencolar(&ColaNew)
+			utils.Desencolar(&utils.Estado.ColaNew)
 		}
+		// Queda en ColaNew hasta que el núcleo compacte y lo vuelva a intentar
 		if alt == "COMPACTACION" {
-
 			NecesitoCompactar = true
-			for utils.Execute != nil {
-				time.Sleep(1000 * time.Millisecond)
-			}
-			if client.Enviar_Body(types.EstructuraEmpty{}, utils.Configs.IpMemory, utils.Configs.PortMemory, "compactar", logger) {
-				logger.Info("Compactacion de Memoria exitosa, reintentando inicializar proceso")
-
-				Inicializar_proceso(new.PCB, new.Pseudo, new.Tamanio, new.Prioridad, logger)
-				utils.Desencolar(&ColaNew)
-
-				NecesitoCompactar = false
-				SignalEnviado = true
-				Semaforo.Signal()
-			}
 		}
 	}
 }
 
-// Se le pasa el pid del proceso a finalizar
-func Finalizar_proceso(pid uint32, logger *slog.Logger) {
+// Se le pasa el pid del proceso a finalizar y la causa; su código de salida es el que recibe el padre con PROCESS_WAIT
+func Finalizar_proceso(pid uint32, causa utils.Causa, logger *slog.Logger) {
 
 	success := client.Enviar_QueryPath(pid, utils.Configs.IpMemory, utils.Configs.PortMemory, "FINALIZAR-PROCESO", "PATCH", logger)
 
 	if success {
-		OK := utils.Enviar_proceso_a_exit(pid, ColaReady, &ColaBlocked, &ColaExit, logger)
+		// Si hay hilos del proceso ejecutando en otras CPUs los sacamos
+		pcb := utils.Obtener_PCB_por_PID(pid)
+		if pcb != nil {
+			for tid := range pcb.TCBs {
+				Desalojar_si_ejecuta(pid, tid, logger)
+			}
+		}
+
+		OK := utils.Estado.Finalizar_proceso(pid, Algoritmo.Quitar, causa, logger)
 		if OK {
-			logger.Info(fmt.Sprintf("## Finaliza el proceso %d", pid))
-			Reintentar_procesos(logger) // Intentar inicializar procesos en ColaNew
+			logger.Info(fmt.Sprintf("## Finaliza el proceso %d - Motivo: %s - Código de salida: %d", pid, causa.Motivo, causa.Codigo))
+			if observador, ok := Algoritmo.(ObservadorProceso); ok {
+				observador.FinDeProceso(pid)
+			}
+			// Queda como zombie para el padre y sus hijos pasan a init
+			Registrar_fin_de_proceso(*pcb, causa.Codigo, logger)
+			Reintentar_procesos(logger)            // Intentar inicializar procesos en ColaNew
+			Reintentar_pedidos_de_recursos(logger) // Los recursos del banquero que tenía quedan disponibles
 		} else {
 			logger.Error("Algo salió mal en Memoria al querer finalizar el proceso")
 		}
@@ -155,11 +122,11 @@ func Finalizar_proceso(pid uint32, logger *slog.Logger) {
 	}
 }
 
-// Recibo de la cpu el archivo de instrucciones y la prioridad
-func Crear_hilo(path string, prioridad int, logger *slog.Logger) {
+// Recibo de la cpu el archivo de instrucciones y la prioridad; pid es el proceso del hilo que hizo la syscall
+func Crear_hilo(pid uint32, path string, prioridad int, logger *slog.Logger) {
 
 	// Crear TCB
-	pcb := utils.Obtener_PCB_por_PID(utils.Execute.PID)
+	pcb := utils.Obtener_PCB_por_PID(pid)
 	if pcb == nil {
 		logger.Error("No se encontro el PCB")
 		return
@@ -177,13 +144,13 @@ func Crear_hilo(path string, prioridad int, logger *slog.Logger) {
 	}
 
 	// Ingresar a la cola de READY
-	utils.Encolar_ColaReady(ColaReady, tcb)
+	Encolar_Ready(tcb)
 
 	logger.Info(fmt.Sprintf("## (%d:%d) Se crea el Hilo - Estado: READY", pcb.PID, tcb.TID))
 }
 
-// Finalizar hilo
-func Finalizar_hilo(TID uint32, PID uint32, logger *slog.Logger) {
+// Finalizar hilo; la causa queda registrada en la cola de exit
+func Finalizar_hilo(TID uint32, PID uint32, causa utils.Causa, logger *slog.Logger) {
 
 	// Informar memoria
 	infoMemoria := types.PIDTID{
@@ -195,230 +162,19 @@ func Finalizar_hilo(TID uint32, PID uint32, logger *slog.Logger) {
 	}
 	logger.Info("Se comunico a memoria la finalizacion del hilo")
 
-	logger.Info(fmt.Sprintf("## (%d:%d) Finaliza el hilo", PID, TID))
+	logger.Info(fmt.Sprintf("## (%d:%d) Finaliza el hilo - Motivo: %s - Código de salida: %d", PID, TID, causa.Motivo, causa.Codigo))
 
-	// Mover al estado de ready lo que estaban bloqueados por ese TID (THREAD_JOIN y MUTEX)
-	utils.Librerar_Bloqueados_De_Hilo(&ColaBlocked, ColaReady, utils.MapaPCB[PID].TCBs[TID], logger)
+	// Si lo cancelaron estando en READY o ejecutando en otra CPU, lo sacamos para que no se vuelva a planificar
+	Algoritmo.Quitar(PID, TID)
+	Desalojar_si_ejecuta(PID, TID, logger)
 
-	// Mandar a la cola de exit
-	utils.Encolar(&ColaExit, utils.MapaPCB[PID].TCBs[TID])
+	// Mover al estado de ready lo que estaban bloqueados por ese TID (THREAD_JOIN) y soltar sus mutex
+	utils.Librerar_Bloqueados_De_Hilo(&utils.Estado.ColaBlocked, Encolar_Ready, utils.Estado.MapaPCB[PID].TCBs[TID], logger)
+	Liberar_mutexes_de_hilo(PID, TID, logger)
 
-	// Quitar de la lista de los TCBs del PCB
-	utils.Sacar_TCB_Del_Map(&utils.MapaPCB, PID, TID, logger)
+	// Mandar a la cola de exit y quitar de la lista de los TCBs del PCB
+	utils.Estado.Finalizar_hilo(PID, TID, causa, logger)
+	Actualizar_prioridades(PID, logger) // Los mutex que tenía o esperaba cambiaron de dueño
 
 	Reintentar_procesos(logger) // Intentar inicializar procesos en ColaNew
 }
-
-// Función que procesa las solicitudes de I/O de la cola
-func Procesar_cola_IO(colaIO *[]utils.SolicitudIO, logger *slog.Logger) {
-	for {
-		solicitud, haySolicitudes := utils.Proxima_solicitud(colaIO)
-		if haySolicitudes {
-			// Simular la duración de la E/S
-			logger.Info(fmt.Sprintf("Procesando E/S para TID %d durante %d ms", solicitud.TID, solicitud.Duracion))
-			time.Sleep(time.Duration(solicitud.Duracion) * time.Millisecond)
-
-			// Una vez terminada la E/S, desbloquear el hilo
-			desbloqueado := utils.Desencolar(&ColaBlocked)
-			pcb := utils.Obtener_PCB_por_PID(desbloqueado.PID)
-			tcb := pcb.TCBs[desbloqueado.TID]
-			logger.Info(fmt.Sprintf("## (%d:%d) finalizó IO y pasa a READY", solicitud.PID, solicitud.TID))
-			utils.Encolar_ColaReady(ColaReady, tcb)
-
-			SignalEnviado = true
-			Semaforo.Signal()
-		} else {
-			// No hay solicitudes en la cola, esperar un tiempo antes de volver a chequear
-			time.Sleep(100 * time.Millisecond)
-		}
-	}
-}
-
-// -------------------------------------- PLANIFICADORES CORTO PLAZO --------------------------------------
-
-func Iniciar_planificador(config utils.Config, logger *slog.Logger) {
-	switch config.SchedulerAlgorithm {
-	case "FIFO":
-		logger.Info("Iniciando planificador FIFO")
-		go FIFO(logger)
-	case "PRIORIDADES":
-		logger.Info("Iniciando planificador por Prioridades")
-		go PRIORIDADES(logger)
-	case "CMN":
-		logger.Info("Iniciando planificador CMN")
-		go COLAS_MULTINIVEL(logger)
-	default:
-		logger.Info("Tipo de planificador no reconocido. Usando FIFO por defecto.")
-		go FIFO(logger) // Por defecto, usa FIFO si no se reconoce el tipo
-	}
-}
-
-func FIFO(logger *slog.Logger) {
-	for {
-		Semaforo.Wait()
-
-		if len(ColaReady[0]) == 0 {
-			logger.Info("No hay procesos en la cola de Ready")
-			time.Sleep(100 * time.Millisecond) // Espera antes de volver a intentar
-			continue
-		}
-		proximo, _ := utils.Desencolar_TCB(ColaReady, 0)
-
-		// Lo ponemos a "ejecutar"
-		utils.Execute = &utils.ExecuteActual{
-			PID: proximo.PID,
-			TID: proximo.TID,
-		}
-
-		client.Enviar_Body(types.PIDTID{TID: utils.Execute.TID, PID: utils.Execute.PID}, utils.Configs.IpCPU, utils.Configs.PortCPU, "EJECUTAR_KERNEL", logger)
-	}
-}
-
-func PRIORIDADES(logger *slog.Logger) {
-	for {
-		Semaforo.Wait()
-
-		if len(ColaReady[0]) > 0 {
-			siguienteHilo := ColaReady[0][0]
-			// Vamos buscando el hilo de menor prioridad (esto a su vez cumple que si hay otro de igual prioridad, desempata por el primero que llegó)
-			for _, tcb := range ColaReady[0] {
-				if tcb.Prioridad < siguienteHilo.Prioridad {
-					siguienteHilo = tcb
-				}
-			}
-			// Vemos si no hay nadie ejecutando o si la prioridad del siguiente hilo es mayor
-			if utils.Execute == nil || siguienteHilo.Prioridad < utils.MapaPCB[utils.Execute.PID].TCBs[utils.Execute.TID].Prioridad {
-
-				if utils.Execute != nil {
-					// Enviamos la interrupción de desalojo por Prioridades
-					client.Enviar_Body(types.InterruptionInfo{NombreInterrupcion: "PRIORIDAD", TID: utils.Execute.TID, PID: utils.Execute.PID}, utils.Configs.IpCPU, utils.Configs.PortCPU, "PRIORIDAD", logger)
-				} else {
-					logger.Info(fmt.Sprintf("Ejecutando hilo %d (PID: %d) con prioridad %d", siguienteHilo.TID, siguienteHilo.PID, siguienteHilo.Prioridad))
-					utils.Execute = &utils.ExecuteActual{
-						PID: siguienteHilo.PID,
-						TID: siguienteHilo.TID,
-					}
-					// Remueve el hilo seleccionado de la cola de READY
-					for i, tcb := range ColaReady[0] {
-						if tcb.TID == siguienteHilo.TID {
-							ColaReady[0] = append(ColaReady[0][:i], ColaReady[0][i+1:]...)
-							break
-						}
-					}
-					client.Enviar_Body_Async(types.PIDTID{TID: utils.Execute.TID, PID: utils.Execute.PID}, utils.Configs.IpCPU, utils.Configs.PortCPU, "EJECUTAR_KERNEL", logger)
-				}
-			} else {
-			}
-		} else {
-			time.Sleep(100 * time.Millisecond) // Espera antes de volver a intentar
-		}
-	}
-}
-
-var SignalEnviado = false
-
-func COLAS_MULTINIVEL(logger *slog.Logger) {
-
-	for {
-
-		Semaforo.Wait()
-		SignalEnviado = false
-		if NecesitoCompactar {
-			utils.Execute = nil
-			continue
-		}
-
-		// Agarro el proximo y lo elimino de la cola de ready
-		proximo, hayAlguien := seleccionarSiguienteHilo()
-
-		// Si no hay nadie en la cola de ready
-		if !hayAlguien {
-			logger.Info("No hay procesos en la cola de Ready")
-			time.Sleep(100 * time.Millisecond) // Espera antes de volver a intentar
-
-			continue
-		}
-
-		// Si hay alguien en la cola de ready
-		if utils.Execute == nil {
-
-			Mu.Lock()
-
-			execID := ExecuteContador + 1
-			utils.Execute = &utils.ExecuteActual{
-				PID:       proximo.PID,
-				TID:       proximo.TID,
-				IDexecute: execID,
-			}
-
-			ExecuteContador = execID
-
-			exec := utils.Execute
-
-			logger.Info(fmt.Sprintf("Ejecutando hilo %d (PID: %d) con prioridad %d", proximo.TID, proximo.PID, proximo.Prioridad))
-
-			utils.Desencolar_TCB(ColaReady, proximo.Prioridad)
-			client.Enviar_Body_Async(types.PIDTID{TID: utils.Execute.TID, PID: utils.Execute.PID}, utils.Configs.IpCPU, utils.Configs.PortCPU, "EJECUTAR_KERNEL", logger)
-			go Quantum(exec, logger) // Comenzamos un hilo para que maneje el quantum
-
-			Mu.Unlock()
-
-		} else if proximo.Prioridad < utils.MapaPCB[utils.Execute.PID].TCBs[utils.Execute.TID].Prioridad {
-
-			client.Enviar_Body(types.InterruptionInfo{NombreInterrupcion: "PRIORIDAD", TID: utils.Execute.TID, PID: utils.Execute.PID}, utils.Configs.IpCPU, utils.Configs.PortCPU, "INTERRUPCION_FIN_QUANTUM", logger)
-
-		} else {
-		}
-
-	}
-
-}
-
-func Quantum(exec *utils.ExecuteActual, logger *slog.Logger) {
-	quantum := time.Duration(utils.Configs.Quantum) * time.Millisecond
-	timer := time.NewTimer(quantum)
-
-	<-timer.C
-
-	Mu.Lock()
-	defer Mu.Unlock()
-
-	if utils.Execute != nil && utils.Execute.IDexecute == exec.IDexecute {
-		client.Enviar_Body(types.InterruptionInfo{NombreInterrupcion: "FIN_QUANTUM", TID: utils.Execute.TID, PID: utils.Execute.PID}, utils.Configs.IpCPU, utils.Configs.PortCPU, "INTERRUPCION_FIN_QUANTUM", logger)
-	}
-}
-
-func seleccionarSiguienteHilo() (types.TCB, bool) {
-
-	// Encontrar el índice máximo de la cola de ready
-	maxIndex := -1
-	for index := range ColaReady {
-		if index > maxIndex {
-			maxIndex = index
-		}
-	}
-
-	// Recorremos las colas desde la de mayor prioridad hasta la menor
-	for prioridad := 0; prioridad <= maxIndex; prioridad++ {
-		if len(ColaReady[prioridad]) > 0 {
-
-			// Tomar el primer hilo de la cola
-			siguienteHilo := ColaReady[prioridad][0]
-			return siguienteHilo, true
-		}
-	}
-	return types.TCB{}, false // No hay hilos disponibles
-}
-
-// No le veo sentido a esta funcion ya que Encolar_ColaReady ya hace lo mismo
-func Meter_A_Planificar_Colas_Multinivel(tcb types.TCB, logger *slog.Logger) {
-
-	// Agrego el tcb a la cola correspondiente, si no existe la cola se crea automáticamente
-	ColaReady[tcb.Prioridad] = append(ColaReady[tcb.Prioridad], tcb)
-}
-
-func InsertarEnPosicion(slice []types.TCB, elemento types.TCB, posicion int) []types.TCB {
-
-	nuevoSlice := append(slice[:posicion], append([]types.TCB{elemento}, slice[posicion:]...)...)
-	return nuevoSlice
-}
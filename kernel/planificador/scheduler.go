@@ -0,0 +1,242 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/client"
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// -------------------------------------- PLANIFICADORES CORTO PLAZO --------------------------------------
+
+// Interfaz que implementa cada algoritmo de planificación de corto plazo.
+// El ciclo de despacho (Planificar) es uno solo, el algoritmo únicamente decide como se
+// ordena la cola de ready, quien es el proximo y cuando corresponde desalojar.
+type Scheduler interface {
+	// Agrega el TCB a la cola de ready
+	Encolar(tcb types.TCB)
+	// Devuelve el proximo TCB a ejecutar SIN sacarlo de la cola de ready; el bool indica si hay alguno
+	Proximo() (types.TCB, bool)
+	// Saca el TCB de la cola de ready; Retorna false si no estaba encolado
+	Quitar(pid uint32, tid uint32) bool
+	// Indica si el candidato tiene que desalojar al hilo que está ejecutando
+	DebeDesalojar(candidato types.TCB, actual types.TCB) bool
+	// Quantum con el que se despacha el TCB (0 si el algoritmo no usa quantum)
+	Quantum(tcb types.TCB) time.Duration
+	// Se llama cuando el TCB fue desalojado por fin de quantum, para que el algoritmo lo vuelva a encolar
+	FinDeQuantum(tcb types.TCB)
+	// Devuelve una copia de la cola de ready separada por nivel
+	Listos() map[int][]types.TCB
+}
+
+// Interfaz opcional para los algoritmos que necesitan arrancar algo propio (por ejemplo un hilo periodico) al iniciar el planificador
+type Iniciable interface {
+	Iniciar(logger *slog.Logger)
+}
+
+// Interfaz opcional para los algoritmos que necesitan enterarse cuando un hilo deja la CPU y cuanto ejecutó (en milisegundos).
+// Se llama con el TCB del mapa de PCBs antes de guardarlo, asi que el algoritmo lo puede modificar
+type ObservadorRafaga interface {
+	FinDeRafaga(tcb *types.TCB, ejecutado float64, desalojado bool)
+}
+
+// Interfaz opcional para los algoritmos que guardan algo por proceso y lo tienen que descartar cuando el proceso finaliza
+type ObservadorProceso interface {
+	FinDeProceso(pid uint32)
+}
+
+// Interfaz opcional para los algoritmos que eligen al azar. Planificar llama a Sortear solo cuando va a despachar en una CPU libre
+// y Proximo devuelve el resultado del sorteo sin volver a sortear, asi consultarlo no avanza la secuencia de la semilla
+type Sorteador interface {
+	Sortear()
+}
+
+// Mapa de algoritmos disponibles, la clave es el valor de scheduler_algorithm en el config
+var algoritmos = make(map[string]func() Scheduler)
+
+// Algoritmo de planificación en uso
+var Algoritmo Scheduler
+
+// Registra un algoritmo de planificación; se llama desde el init() del archivo que lo implementa
+func Registrar_algoritmo(nombre string, constructor func() Scheduler) {
+	algoritmos[nombre] = constructor
+}
+
+func Iniciar_planificador(config utils.Config, logger *slog.Logger) {
+	constructor, existe := algoritmos[config.SchedulerAlgorithm]
+	if !existe {
+		logger.Info("Tipo de planificador no reconocido. Usando FIFO por defecto.")
+		constructor = algoritmos["FIFO"] // Por defecto, usa FIFO si no se reconoce el tipo
+	} else {
+		logger.Info(fmt.Sprintf("Iniciando planificador %s", config.SchedulerAlgorithm))
+	}
+	// Los hilos de tiempo real siempre se planifican por delante del algoritmo elegido
+	Algoritmo = Con_tiempo_real(constructor(), config.RtAlgorithm)
+	if iniciable, ok := Algoritmo.(Iniciable); ok {
+		iniciable.Iniciar(logger)
+	}
+	go Nucleo(logger)
+}
+
+// Pasa el TCB a READY según el algoritmo de planificación en uso; si su proceso está detenido queda en STOPPED hasta el PROCESS_CONT
+func Encolar_Ready(tcb types.TCB) {
+	if utils.Estado.Detenidos[tcb.PID] {
+		utils.Encolar(&utils.Estado.ColaStopped, tcb)
+		return
+	}
+	Algoritmo.Encolar(tcb)
+}
+
+// El operador pausó la planificación desde la consola
+var Pausado bool
+
+// Despacho comun a todos los algoritmos, lo llama el núcleo despues de procesar cada evento
+func Planificar(logger *slog.Logger) {
+	// Mientras se espera para compactar no se despacha a nadie
+	if NecesitoCompactar {
+		Compactar_si_corresponde(logger)
+		return
+	}
+
+	// Con la planificación pausada los hilos que ejecutan siguen, pero no se despacha ni se desaloja a nadie
+	if Pausado {
+		return
+	}
+
+	// Despachamos mientras haya CPUs libres y hilos en READY
+	for cpu := utils.CPU_libre(); cpu != -1; cpu = utils.CPU_libre() {
+		if sorteador, ok := Algoritmo.(Sorteador); ok {
+			sorteador.Sortear()
+		}
+		proximo, hayAlguien := Algoritmo.Proximo()
+		if !hayAlguien {
+			return
+		}
+		Despachar(proximo, cpu, logger)
+	}
+
+	// Si quedó alguien esperando vemos si corresponde que desaloje a alguno de los que están ejecutando.
+	// Acá no se sortea: la lotería no desaloja, el candidato solo importa si es de tiempo real
+	if proximo, hayAlguien := Algoritmo.Proximo(); hayAlguien {
+		if victima := Elegir_victima(proximo); victima != nil {
+			victima.Desalojando = true
+			Enviar_interrupcion(victima, "PRIORIDAD", "PRIORIDAD", logger)
+		}
+	}
+}
+
+// Devuelve el primer hilo en ejecución que el candidato debería desalojar, nil si no hay ninguno.
+// No se tiene en cuenta a los que ya se les mandó una interrupción y todavia no volvieron
+func Elegir_victima(candidato types.TCB) *utils.ExecuteActual {
+	for _, exec := range utils.Hilos_ejecutando() {
+		if exec.Desalojando {
+			continue
+		}
+		actual, existe := utils.Estado.MapaPCB[exec.PID].TCBs[exec.TID]
+		if existe && Algoritmo.DebeDesalojar(candidato, actual) {
+			return exec
+		}
+	}
+	return nil
+}
+
+// Envia la interrupción a la CPU en la que está ejecutando el hilo
+func Enviar_interrupcion(exec *utils.ExecuteActual, nombre string, endpoint string, logger *slog.Logger) {
+	cpu := utils.Configs.CPUs[exec.CPU]
+	client.Enviar_Body(types.InterruptionInfo{NombreInterrupcion: nombre, TID: exec.TID, PID: exec.PID}, cpu.Ip, cpu.Port, endpoint, logger)
+}
+
+// Saca el TCB de ready, lo pone a ejecutar en la CPU indicada y si el algoritmo usa quantum arranca el timer
+func Despachar(proximo types.TCB, cpu int, logger *slog.Logger) {
+	execID := ExecuteContador + 1
+	exec := &utils.ExecuteActual{
+		PID:       proximo.PID,
+		TID:       proximo.TID,
+		IDexecute: execID,
+		Inicio:    time.Now(),
+		CPU:       cpu,
+	}
+	utils.Estado.Ocupar_CPU(exec)
+	ExecuteContador = execID
+
+	logger.Info(fmt.Sprintf("Ejecutando hilo %d (PID: %d) con prioridad %d en la CPU %d", proximo.TID, proximo.PID, proximo.Prioridad, cpu))
+
+	Algoritmo.Quitar(proximo.PID, proximo.TID)
+	client.Enviar_Body_Async(types.PIDTID{TID: exec.TID, PID: exec.PID}, utils.Configs.CPUs[cpu].Ip, utils.Configs.CPUs[cpu].Port, "EJECUTAR_KERNEL", logger)
+
+	if quantum := Algoritmo.Quantum(proximo); quantum > 0 {
+		Iniciar_quantum(exec, quantum, logger)
+	}
+
+	// Si su proceso tiene señales pendientes lo interrumpimos enseguida para poder entregarlas
+	if Hay_seniales_pendientes(proximo.PID) {
+		Interrumpir_por_senial(exec, logger)
+	}
+}
+
+// Cierra la ráfaga del hilo y libera la CPU en la que estaba ejecutando.
+// Si fue desalojado la ráfaga no terminó, solo se acumula lo ejecutado; sino se actualiza la estimación del TCB
+func Terminar_rafaga(exec *utils.ExecuteActual, desalojado bool) {
+	if !utils.Estado.Liberar_CPU(exec) {
+		return
+	}
+	contarRafaga(exec, desalojado)
+}
+
+// Acumula en el TCB lo que ejecutó en la ráfaga y, si no fue desalojado, actualiza su estimación
+func contarRafaga(exec *utils.ExecuteActual, desalojado bool) {
+	// Si el hilo ya finalizó no hay nada que actualizar
+	tcb, existe := utils.Estado.MapaPCB[exec.PID].TCBs[exec.TID]
+	if !existe {
+		return
+	}
+
+	ejecutado := milisegundos(time.Since(exec.Inicio))
+	tcb.RafagaActual += ejecutado
+	tcb.TiempoCPU += ejecutado
+	if !desalojado {
+		tcb.Estimacion = Estimar_rafaga(tcb.Estimacion, tcb.RafagaActual)
+		tcb.RafagaActual = 0
+	}
+	if observador, ok := Algoritmo.(ObservadorRafaga); ok {
+		observador.FinDeRafaga(&tcb, ejecutado, desalojado)
+	}
+	utils.Estado.Actualizar_TCB(tcb)
+}
+
+// Bloquea al hilo que está ejecutando: lo pasa a la cola de bloqueados y libera su CPU. Retorna el ID del bloqueo;
+// el bool es false si el hilo ya no existe: no se bloquea y se libera la CPU que esperaba su desalojo
+func Bloquear_hilo(exec *utils.ExecuteActual, bloqueado utils.Bloqueado, logger *slog.Logger) (int, bool) {
+	id, existe := utils.Estado.Bloquear_hilo(bloqueado)
+	if !existe {
+		logger.Error(fmt.Sprintf("## (%d:%d) - No se puede bloquear por %s, el hilo ya no existe", bloqueado.PID, bloqueado.TID, bloqueado.Motivo))
+		utils.Estado.Liberar_CPU(exec)
+		return 0, false
+	}
+	logger.Info(fmt.Sprintf("## (%d:%d) - Bloqueado por: %s", bloqueado.PID, bloqueado.TID, bloqueado.Motivo))
+	Terminar_rafaga(exec, false)
+	return id, true
+}
+
+// Saca el bloqueo con ese ID de la cola de bloqueados y pasa al hilo a READY; Retorna false si ya no estaba bloqueado
+func Desbloquear_hilo(id int) bool {
+	tcb, existe := utils.Estado.Desbloquear_hilo(id)
+	if existe {
+		Encolar_Ready(tcb)
+	}
+	return existe
+}
+
+// Saca al hilo de la CPU si está ejecutando (por ejemplo cuando otro hilo lo cancela o finaliza su proceso).
+// La CPU sigue ocupada hasta que avisa con el desalojo FINALIZACION que lo sacó: mientras tanto sigue ejecutando
+// al hilo, asi que no se le puede despachar otro ni mandarle otra interrupción que pise a esta
+func Desalojar_si_ejecuta(pid uint32, tid uint32, logger *slog.Logger) {
+	if exec := utils.Buscar_Execute(pid, tid); exec != nil {
+		contarRafaga(exec, false)
+		exec.Desalojando = true
+		Enviar_interrupcion(exec, "FINALIZACION", "INTERRUPCION", logger)
+	}
+}
@@ -0,0 +1,133 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+)
+
+// -------------------------------------- SEÑALES --------------------------------------
+
+// Las señales no se aplican en el momento: quedan pendientes hasta que ningún hilo al que afectan esté en una CPU.
+// A los que están ejecutando se les manda una interrupción SENIAL; cuando vuelven (por la interrupción o porque la
+// syscall que hicieron termina su ráfaga) el núcleo las entrega despues del evento. Tambien se revisan al despachar.
+// Todas se llaman dentro de un evento del núcleo
+
+// Deja la señal pendiente; Retorna false si no existe el proceso (o el hilo, para THREAD_KILL)
+func Enviar_senial(senial utils.Senial, logger *slog.Logger) bool {
+	pcb, existe := utils.Estado.MapaPCB[senial.PID]
+	if !existe {
+		return false
+	}
+	if senial.Tipo == "THREAD_KILL" {
+		if _, existe := pcb.TCBs[senial.TID]; !existe {
+			return false
+		}
+	}
+	utils.Encolar(&utils.Estado.Seniales, senial)
+	logger.Info(fmt.Sprintf("## (%d:%d) - Señal %s pendiente", senial.PID, senial.TID, senial.Tipo))
+	return true
+}
+
+// Indica si el proceso tiene alguna señal sin entregar
+func Hay_seniales_pendientes(pid uint32) bool {
+	for _, senial := range utils.Estado.Seniales {
+		if senial.PID == pid {
+			return true
+		}
+	}
+	return false
+}
+
+// Entrega en orden las señales cuyos hilos no están ejecutando. Si una señal tiene que esperar, las siguientes del mismo
+// proceso también esperan para que se apliquen en el orden en que llegaron
+func Entregar_seniales(logger *slog.Logger) {
+	var pendientes []utils.Senial
+	esperando := make(map[uint32]bool)
+
+	for _, senial := range utils.Estado.Seniales {
+		// Si el proceso ya finalizó la señal se descarta
+		if _, existe := utils.Estado.MapaPCB[senial.PID]; !existe {
+			continue
+		}
+
+		ejecutando := hilosAfectadosEnEjecucion(senial)
+		if esperando[senial.PID] || len(ejecutando) > 0 {
+			for _, exec := range ejecutando {
+				Interrumpir_por_senial(exec, logger)
+			}
+			esperando[senial.PID] = true
+			pendientes = append(pendientes, senial)
+			continue
+		}
+		Entregar_senial(senial, logger)
+	}
+	utils.Estado.Seniales = pendientes
+}
+
+// Hilos en ejecución a los que afecta la señal: el hilo en THREAD_KILL, cualquiera del proceso en las demás
+func hilosAfectadosEnEjecucion(senial utils.Senial) []*utils.ExecuteActual {
+	var afectados []*utils.ExecuteActual
+	for _, exec := range utils.Hilos_ejecutando() {
+		if exec.PID == senial.PID && (senial.Tipo != "THREAD_KILL" || exec.TID == senial.TID) {
+			afectados = append(afectados, exec)
+		}
+	}
+	return afectados
+}
+
+// Manda a la CPU la interrupción para que el hilo deje de ejecutar (si no se le mandó ya otra)
+func Interrumpir_por_senial(exec *utils.ExecuteActual, logger *slog.Logger) {
+	if exec.Desalojando {
+		return
+	}
+	exec.Desalojando = true
+	Enviar_interrupcion(exec, "SENIAL", "INTERRUPCION", logger)
+}
+
+// Aplica la señal; ninguno de los hilos a los que afecta está ejecutando
+func Entregar_senial(senial utils.Senial, logger *slog.Logger) {
+	logger.Info(fmt.Sprintf("## (%d:%d) - Se entrega la señal %s", senial.PID, senial.TID, senial.Tipo))
+	switch senial.Tipo {
+	case "PROCESS_KILL":
+		Finalizar_proceso(senial.PID, utils.FIN_PROCESS_KILL, logger)
+	case "THREAD_KILL":
+		if _, existe := utils.Estado.MapaPCB[senial.PID].TCBs[senial.TID]; existe {
+			Finalizar_hilo(senial.TID, senial.PID, utils.FIN_THREAD_KILL, logger)
+		}
+	case "PROCESS_STOP":
+		Detener_proceso(senial.PID, logger)
+	case "PROCESS_CONT":
+		Continuar_proceso(senial.PID, logger)
+	}
+}
+
+// Pasa los hilos en READY del proceso a STOPPED. Los bloqueados siguen en BLOCKED y al desbloquearse van a STOPPED
+func Detener_proceso(pid uint32, logger *slog.Logger) {
+	if utils.Estado.Detenidos[pid] {
+		return
+	}
+	utils.Estado.Detenidos[pid] = true
+	for tid, tcb := range utils.Estado.MapaPCB[pid].TCBs {
+		if Algoritmo.Quitar(pid, tid) {
+			utils.Encolar(&utils.Estado.ColaStopped, tcb)
+		}
+	}
+	logger.Info(fmt.Sprintf("## (%d) - Proceso detenido", pid))
+}
+
+// Vuelve a pasar a READY los hilos del proceso que estaban en STOPPED
+func Continuar_proceso(pid uint32, logger *slog.Logger) {
+	if !utils.Estado.Detenidos[pid] {
+		return
+	}
+	delete(utils.Estado.Detenidos, pid)
+	for _, detenido := range utils.Estado.Quitar_detenidos(pid, 0, true) {
+		// El TCB del mapa puede haber cambiado mientras estaba detenido (por ejemplo su prioridad efectiva)
+		if tcb, existe := utils.Estado.MapaPCB[pid].TCBs[detenido.TID]; existe {
+			Encolar_Ready(tcb)
+		}
+	}
+	logger.Info(fmt.Sprintf("## (%d) - Proceso continúa", pid))
+}
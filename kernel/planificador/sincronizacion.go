@@ -0,0 +1,208 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// -------------------------------------- MUTEX, SEMÁFOROS Y CONDICIONES --------------------------------------
+// Todas se llaman dentro de un evento del núcleo
+
+// Finaliza el hilo que está ejecutando porque usó un recurso que no existe (mutex, dispositivo, cola, segmento, hijo...)
+func Finalizar_hilo_en_ejecucion(exec *utils.ExecuteActual, logger *slog.Logger) {
+	Terminar_rafaga(exec, false)
+	Finalizar_hilo(exec.TID, exec.PID, utils.FIN_RECURSO_INEXISTENTE, logger)
+}
+
+// Libera el mutex del proceso: si hay hilos esperándolo se lo asigna a uno según mutex_handoff y lo pasa a READY.
+// Retorna false si nadie lo esperaba y quedó LIBRE
+func Liberar_mutex(pid uint32, mutex string, logger *slog.Logger) bool {
+	defer Actualizar_prioridades(pid, logger)
+
+	bloqueado, existe := Siguiente_duenio(pid, mutex)
+	if !existe {
+		utils.Estado.MapaPCB[pid].Mutexs[mutex] = "LIBRE"
+		logger.Info(fmt.Sprintf("## %s quedo LIBRE", mutex))
+		return false
+	}
+
+	utils.Estado.MapaPCB[pid].Mutexs[mutex] = strconv.Itoa(int(bloqueado.TID))
+	Desbloquear_hilo(bloqueado.ID)
+	logger.Info(fmt.Sprintf("## (%d:%d) - Desbloqueado por: MUTEX y asignado a el", bloqueado.PID, bloqueado.TID))
+	return true
+}
+
+// Elige entre los hilos que esperan el mutex al que se lo lleva: FIFO el primero que llegó, PRIORIDAD el de mayor
+// prioridad efectiva (ante empate el primero que llegó) y ALEATORIO uno al azar
+func Siguiente_duenio(pid uint32, mutex string) (utils.Bloqueado, bool) {
+	cola := utils.Bloqueados_por(utils.Estado.ColaBlocked, pid, utils.Mutex, mutex)
+	if len(cola) == 0 {
+		return utils.Bloqueado{}, false
+	}
+
+	switch utils.Configs.PoliticaMutex {
+	case "PRIORIDAD":
+		elegido := cola[0]
+		tcbs := utils.Estado.MapaPCB[pid].TCBs
+		for _, bloqueado := range cola {
+			if tcbs[bloqueado.TID].Prioridad < tcbs[elegido.TID].Prioridad {
+				elegido = bloqueado
+			}
+		}
+		return elegido, true
+	case "ALEATORIO":
+		return cola[utils.Random.Intn(len(cola))], true
+	}
+	return cola[0], true
+}
+
+// Suelta todos los mutex que tiene el hilo (porque finaliza o lo cancelan) para que no queden hilos esperándolos para siempre
+func Liberar_mutexes_de_hilo(pid uint32, tid uint32, logger *slog.Logger) {
+	pcb, existe := utils.Estado.MapaPCB[pid]
+	if !existe {
+		return
+	}
+	for mutex, duenio := range pcb.Mutexs {
+		if duenio == strconv.Itoa(int(tid)) {
+			logger.Info(fmt.Sprintf("## (%d:%d) - Libera %s al finalizar", pid, tid, mutex))
+			Liberar_mutex(pid, mutex, logger)
+		}
+	}
+}
+
+// Le da el mutex a un hilo que no está ejecutando: si está libre lo toma y pasa a READY, sino queda bloqueado esperándolo
+func Tomar_o_esperar_mutex(tcb types.TCB, mutex string, logger *slog.Logger) {
+	defer Actualizar_prioridades(tcb.PID, logger)
+
+	pcb := utils.Estado.MapaPCB[tcb.PID]
+	if pcb.Mutexs[mutex] == "LIBRE" {
+		pcb.Mutexs[mutex] = strconv.Itoa(int(tcb.TID))
+		Encolar_Ready(tcb)
+		return
+	}
+	if _, existe := utils.Estado.Bloquear_hilo(utils.Bloqueado{PID: tcb.PID, TID: tcb.TID, Motivo: utils.Mutex, QuienFue: mutex}); !existe {
+		return
+	}
+	logger.Info(fmt.Sprintf("## (%d:%d) - Bloqueado por: %s", tcb.PID, tcb.TID, utils.Mutex))
+}
+
+// Despierta al primer hilo que espera el semáforo; si no hay ninguno incrementa el contador
+func Liberar_semaforo(pid uint32, semaforo string, logger *slog.Logger) {
+	bloqueado, existe := utils.Primer_bloqueado(utils.Estado.ColaBlocked, pid, utils.Semaforo, semaforo)
+	if !existe {
+		utils.Estado.MapaPCB[pid].Semaforos[semaforo]++
+		return
+	}
+	Desbloquear_hilo(bloqueado.ID)
+	logger.Info(fmt.Sprintf("## (%d:%d) - Desbloqueado por: SEMAFORO %s", bloqueado.PID, bloqueado.TID, semaforo))
+}
+
+// Despierta a los hilos que esperan la condición (a todos o solo al primero); cada uno vuelve a competir por su mutex
+// antes de pasar a READY. Retorna cuántos despertó
+func Despertar_de_condicion(pid uint32, condicion string, todos bool, logger *slog.Logger) int {
+	despertados := 0
+	for {
+		bloqueado, existe := utils.Primer_bloqueado(utils.Estado.ColaBlocked, pid, utils.Condicion, condicion)
+		if !existe {
+			return despertados
+		}
+		if tcb, existe := utils.Estado.Desbloquear_hilo(bloqueado.ID); existe {
+			logger.Info(fmt.Sprintf("## (%d:%d) - Desbloqueado por: CONDICION %s", bloqueado.PID, bloqueado.TID, condicion))
+			Tomar_o_esperar_mutex(tcb, bloqueado.Mutex, logger)
+		}
+		despertados++
+		if !todos {
+			return despertados
+		}
+	}
+}
+
+// Con preferencia ESCRITORES los lectores nuevos no toman el rwlock si hay un escritor esperando
+func Prefiere_escritores() bool {
+	return utils.Configs.RwlockPreferencia == "ESCRITORES"
+}
+
+// Un lector puede tomar el rwlock si no hay escritor (y, si se prefiere a los escritores, ninguno esperando)
+func Puede_leer(pid uint32, nombre string) bool {
+	lock := utils.Estado.MapaPCB[pid].RWLocks[nombre]
+	if lock.Escritor != "LIBRE" {
+		return false
+	}
+	return !Prefiere_escritores() || len(utils.Bloqueados_por(utils.Estado.ColaBlocked, pid, utils.Escritura, nombre)) == 0
+}
+
+// Un escritor solo puede tomar el rwlock si no lo tiene nadie
+func Puede_escribir(pid uint32, nombre string) bool {
+	lock := utils.Estado.MapaPCB[pid].RWLocks[nombre]
+	return lock.Escritor == "LIBRE" && len(lock.Lectores) == 0
+}
+
+// Saca al hilo de los que tienen tomado el rwlock; Retorna false si no lo tenía
+func Soltar_rwlock(pid uint32, tid uint32, nombre string) bool {
+	pcb := utils.Estado.MapaPCB[pid]
+	lock := pcb.RWLocks[nombre]
+	defer func() { pcb.RWLocks[nombre] = lock }()
+
+	if lock.Escritor == strconv.Itoa(int(tid)) {
+		lock.Escritor = "LIBRE"
+		return true
+	}
+	for i, lector := range lock.Lectores {
+		if lector == tid {
+			lock.Lectores = append(lock.Lectores[:i:i], lock.Lectores[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Le da el rwlock a los hilos que lo esperan: al primer escritor si no lo tiene nadie y le toca a los escritores
+// (o no hay lectores esperando), sino a todos los lectores que esperan
+func Despertar_rwlock(pid uint32, nombre string, logger *slog.Logger) {
+	pcb := utils.Estado.MapaPCB[pid]
+	lock := pcb.RWLocks[nombre]
+	if lock.Escritor != "LIBRE" {
+		return
+	}
+
+	lectores := utils.Bloqueados_por(utils.Estado.ColaBlocked, pid, utils.Lectura, nombre)
+	escritores := utils.Bloqueados_por(utils.Estado.ColaBlocked, pid, utils.Escritura, nombre)
+
+	if len(escritores) > 0 && len(lock.Lectores) == 0 && (Prefiere_escritores() || len(lectores) == 0) {
+		lock.Escritor = strconv.Itoa(int(escritores[0].TID))
+		pcb.RWLocks[nombre] = lock
+		Desbloquear_hilo(escritores[0].ID)
+		logger.Info(fmt.Sprintf("## (%d:%d) - Desbloqueado por: RWLOCK %s en escritura", escritores[0].PID, escritores[0].TID, nombre))
+		return
+	}
+	if len(escritores) > 0 && Prefiere_escritores() {
+		return
+	}
+
+	for _, lector := range lectores {
+		lock.Lectores = append(lock.Lectores, lector.TID)
+		Desbloquear_hilo(lector.ID)
+		logger.Info(fmt.Sprintf("## (%d:%d) - Desbloqueado por: RWLOCK %s en lectura", lector.PID, lector.TID, nombre))
+	}
+	pcb.RWLocks[nombre] = lock
+}
+
+// El hilo que ejecuta llega a la barrera: si es el último que faltaba libera a todos los que esperan y sigue ejecutando,
+// sino se bloquea (si el hilo ya no existe no se bloquea ni libera nada). Retorna true si la barrera se liberó
+func Llegar_a_barrera(exec *utils.ExecuteActual, nombre string, logger *slog.Logger) bool {
+	esperando := utils.Bloqueados_por(utils.Estado.ColaBlocked, exec.PID, utils.Barrera, nombre)
+	if len(esperando)+1 < utils.Estado.MapaPCB[exec.PID].Barreras[nombre] {
+		Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.Barrera, QuienFue: nombre}, logger)
+		return false
+	}
+
+	for _, bloqueado := range esperando {
+		Desbloquear_hilo(bloqueado.ID)
+	}
+	logger.Info(fmt.Sprintf("## Barrera %s liberada: llegaron %d hilos", nombre, len(esperando)+1))
+	return true
+}
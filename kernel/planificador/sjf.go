@@ -0,0 +1,60 @@
+package planificador
+
+import (
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+func init() {
+	Registrar_algoritmo("SJF", func() Scheduler { return sjf{} })
+	Registrar_algoritmo("SRT", func() Scheduler { return srt{} })
+}
+
+// SJF: una sola cola (nivel 0), se elige el hilo con menor ráfaga restante estimada, sin desalojo
+type sjf struct {
+	fifo
+}
+
+func (sjf) Proximo() (types.TCB, bool) {
+	if len(utils.Estado.ColaReady[0]) == 0 {
+		return types.TCB{}, false
+	}
+	siguienteHilo := utils.Estado.ColaReady[0][0]
+	// Ante igual estimación desempata por el primero que llegó
+	for _, tcb := range utils.Estado.ColaReady[0] {
+		if Rafaga_restante(tcb) < Rafaga_restante(siguienteHilo) {
+			siguienteHilo = tcb
+		}
+	}
+	return siguienteHilo, true
+}
+
+// SRT: igual que SJF pero desaloja al que está ejecutando si el candidato tiene una ráfaga restante menor
+type srt struct {
+	sjf
+}
+
+func (srt) DebeDesalojar(candidato types.TCB, actual types.TCB) bool {
+	restanteActual := Rafaga_restante(actual)
+	if exec := utils.Buscar_Execute(actual.PID, actual.TID); exec != nil {
+		restanteActual -= milisegundos(time.Since(exec.Inicio))
+	}
+	return Rafaga_restante(candidato) < restanteActual
+}
+
+// Lo que le falta al hilo para terminar su ráfaga según la estimación
+func Rafaga_restante(tcb types.TCB) float64 {
+	return tcb.Estimacion - tcb.RafagaActual
+}
+
+// Promedio exponencial: Est(n+1) = alfa * R(n) + (1 - alfa) * Est(n)
+func Estimar_rafaga(estimacion float64, rafagaReal float64) float64 {
+	alfa := utils.Configs.BurstAlpha
+	return alfa*rafagaReal + (1-alfa)*estimacion
+}
+
+func milisegundos(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
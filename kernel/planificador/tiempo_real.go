@@ -0,0 +1,224 @@
+package planificador
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Nivel con el que aparecen los hilos de tiempo real en Listos(), antes que cualquier nivel del algoritmo normal
+const NIVEL_TIEMPO_REAL = -1
+
+// Clase de tiempo real: los hilos periódicos (declarados con THREAD_SET_RT) se planifican con EDF o RM
+// por delante del algoritmo normal, que solo elige cuando no hay ningún hilo de tiempo real en READY.
+// El hilo indica que terminó su trabajo con THREAD_JOB_END: se cuenta si venció su plazo y el siguiente trabajo se
+// libera un periodo después del anterior. Hasta esa liberación el hilo queda bloqueado, asi no vuelve a la cola
+// de tiempo real antes de tiempo y no le quita la CPU a los hilos normales
+type tiempoReal struct {
+	normal   Scheduler
+	politica string // EDF o RM
+	listos   []types.TCB
+}
+
+// Envuelve el algoritmo normal con la clase de tiempo real (rt_algorithm del config, EDF por defecto)
+func Con_tiempo_real(normal Scheduler, politica string) Scheduler {
+	if politica != "RM" {
+		politica = "EDF"
+	}
+	return &tiempoReal{normal: normal, politica: politica}
+}
+
+func Es_tiempo_real(tcb types.TCB) bool {
+	return tcb.Periodo > 0
+}
+
+// Indica si a es más urgente que b: en EDF el de vencimiento más cercano, en RM el de menor periodo
+func (t *tiempoReal) masUrgente(a types.TCB, b types.TCB) bool {
+	if t.politica == "RM" {
+		return a.Periodo < b.Periodo
+	}
+	return a.Vencimiento.Before(b.Vencimiento)
+}
+
+func (t *tiempoReal) Encolar(tcb types.TCB) {
+	if !Es_tiempo_real(tcb) {
+		t.normal.Encolar(tcb)
+		return
+	}
+	t.quitarDeTiempoReal(tcb.PID, tcb.TID)
+	t.listos = append(t.listos, tcb)
+}
+
+func (t *tiempoReal) Proximo() (types.TCB, bool) {
+	if len(t.listos) == 0 {
+		return t.normal.Proximo()
+	}
+	// Ante igual urgencia desempata por el primero que llegó
+	siguienteHilo := t.listos[0]
+	for _, tcb := range t.listos {
+		if t.masUrgente(tcb, siguienteHilo) {
+			siguienteHilo = tcb
+		}
+	}
+	return siguienteHilo, true
+}
+
+// Solo sortea el algoritmo normal, y solo si no hay ningún hilo de tiempo real que vaya antes
+func (t *tiempoReal) Sortear() {
+	if sorteador, ok := t.normal.(Sorteador); ok && len(t.listos) == 0 {
+		sorteador.Sortear()
+	}
+}
+
+func (t *tiempoReal) Quitar(pid uint32, tid uint32) bool {
+	if t.quitarDeTiempoReal(pid, tid) {
+		return true
+	}
+	return t.normal.Quitar(pid, tid)
+}
+
+func (t *tiempoReal) quitarDeTiempoReal(pid uint32, tid uint32) bool {
+	for i, tcb := range t.listos {
+		if tcb.PID == pid && tcb.TID == tid {
+			t.listos = append(t.listos[:i], t.listos[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Un hilo de tiempo real desaloja a cualquier hilo normal y a los de tiempo real menos urgentes
+func (t *tiempoReal) DebeDesalojar(candidato types.TCB, actual types.TCB) bool {
+	switch {
+	case Es_tiempo_real(candidato) && Es_tiempo_real(actual):
+		return t.masUrgente(candidato, actual)
+	case Es_tiempo_real(candidato):
+		return true
+	case Es_tiempo_real(actual):
+		return false
+	}
+	return t.normal.DebeDesalojar(candidato, actual)
+}
+
+// Los hilos de tiempo real no tienen quantum, ejecutan hasta terminar el trabajo o ser desalojados por uno más urgente
+func (t *tiempoReal) Quantum(tcb types.TCB) time.Duration {
+	if Es_tiempo_real(tcb) {
+		return 0
+	}
+	return t.normal.Quantum(tcb)
+}
+
+func (t *tiempoReal) FinDeQuantum(tcb types.TCB) {
+	if Es_tiempo_real(tcb) {
+		t.Encolar(tcb)
+		return
+	}
+	t.normal.FinDeQuantum(tcb)
+}
+
+func (t *tiempoReal) Listos() map[int][]types.TCB {
+	listos := t.normal.Listos()
+	if len(t.listos) > 0 {
+		listos[NIVEL_TIEMPO_REAL] = append([]types.TCB(nil), t.listos...)
+	}
+	return listos
+}
+
+func (t *tiempoReal) Iniciar(logger *slog.Logger) {
+	if iniciable, ok := t.normal.(Iniciable); ok {
+		iniciable.Iniciar(logger)
+	}
+}
+
+func (t *tiempoReal) FinDeProceso(pid uint32) {
+	if observador, ok := t.normal.(ObservadorProceso); ok {
+		observador.FinDeProceso(pid)
+	}
+}
+
+// Para un hilo de tiempo real dejar la CPU (por una syscall, un bloqueo o un desalojo) no termina el trabajo,
+// eso lo indica el propio hilo con THREAD_JOB_END
+func (t *tiempoReal) FinDeRafaga(tcb *types.TCB, ejecutado float64, desalojado bool) {
+	if Es_tiempo_real(*tcb) {
+		return
+	}
+	if observador, ok := t.normal.(ObservadorRafaga); ok {
+		observador.FinDeRafaga(tcb, ejecutado, desalojado)
+	}
+}
+
+// El hilo periódico que está ejecutando terminó su trabajo: se cuenta si venció el plazo y se libera el siguiente.
+// Si la liberación todavia no llegó lo bloquea y un timer lo pasa a READY desde el núcleo; si ya pasó (el hilo viene
+// atrasado) sigue ejecutando con el trabajo nuevo. Retorna true si sigue ejecutando
+func Terminar_trabajo(exec *utils.ExecuteActual, logger *slog.Logger) bool {
+	tcb := utils.Estado.MapaPCB[exec.PID].TCBs[exec.TID]
+	if time.Now().After(tcb.Vencimiento) {
+		tcb.VencimientosPerdidos++
+		logger.Info(fmt.Sprintf("## (%d:%d) - Terminó el trabajo despues de su plazo (%d vencimientos perdidos)", exec.PID, exec.TID, tcb.VencimientosPerdidos))
+	}
+	Liberar_trabajo(&tcb, tcb.Liberacion.Add(time.Duration(tcb.Periodo)*time.Millisecond))
+	utils.Estado.Actualizar_TCB(tcb)
+
+	espera := time.Until(tcb.Liberacion)
+	if espera <= 0 {
+		return true
+	}
+
+	id, bloqueado := Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.Periodo, QuienFue: strconv.Itoa(tcb.Periodo)}, logger)
+	if !bloqueado {
+		return false
+	}
+	time.AfterFunc(espera, func() {
+		Notificar(EventosReady, func() {
+			// Si lo finalizaron mientras esperaba ya no está bloqueado
+			if Desbloquear_hilo(id) {
+				logger.Info(fmt.Sprintf("## (%d:%d) - Se libera un nuevo trabajo y pasa a READY", exec.PID, exec.TID))
+			}
+		})
+	})
+	return false
+}
+
+// Marca el comienzo de un nuevo trabajo del hilo periódico y calcula su vencimiento
+func Liberar_trabajo(tcb *types.TCB, liberacion time.Time) {
+	tcb.Liberacion = liberacion
+	tcb.Vencimiento = liberacion.Add(time.Duration(tcb.Plazo) * time.Millisecond)
+}
+
+// Test de admisión: verifica que el conjunto de hilos de tiempo real siga siendo planificable si se agrega el candidato.
+// EDF: sum(C / min(D, T)) <= 1. RM: cota de Liu y Layland sum(C / min(D, T)) <= n * (2^(1/n) - 1).
+// Con plazo igual al periodo el test de EDF es exacto; en los demás casos es suficiente pero no necesario
+func Admitir_tiempo_real(candidato types.TCB) error {
+	utilizacion := float64(candidato.WCET) / float64(min(candidato.Plazo, candidato.Periodo))
+	n := 1
+	for _, pcb := range utils.Estado.MapaPCB {
+		for _, tcb := range pcb.TCBs {
+			if !Es_tiempo_real(tcb) || (tcb.PID == candidato.PID && tcb.TID == candidato.TID) {
+				continue
+			}
+			utilizacion += float64(tcb.WCET) / float64(min(tcb.Plazo, tcb.Periodo))
+			n++
+		}
+	}
+
+	limite := 1.0
+	if politicaTiempoReal() == "RM" {
+		limite = float64(n) * (math.Pow(2, 1/float64(n)) - 1)
+	}
+	if utilizacion > limite {
+		return fmt.Errorf("utilización %.3f supera el límite %.3f de %s con %d hilos", utilizacion, limite, politicaTiempoReal(), n)
+	}
+	return nil
+}
+
+func politicaTiempoReal() string {
+	if t, ok := Algoritmo.(*tiempoReal); ok {
+		return t.politica
+	}
+	return "EDF"
+}
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/planificador"
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Endpoints de administración de solo lectura: devuelven una foto del estado del kernel como JSON.
+// La respuesta se arma y se codifica dentro de una consulta al núcleo, asi no se lee el estado mientras se modifica
+// y, como la consulta no planifica, leer no despacha hilos ni cambia nada.
+// Las listas salen ordenadas por PID y TID para que dos consultas seguidas sin cambios devuelvan lo mismo
+
+// Resumen de un proceso en GET /processes
+type ResumenProceso struct {
+	PID      uint32    `json:"pid"`
+	Padre    uint32    `json:"padre"`
+	Estado   string    `json:"estado"` // NEW, STOPPED o ACTIVO
+	Hilos    int       `json:"hilos"`
+	Creacion time.Time `json:"creacion"`
+}
+
+// Un hilo con el estado en el que está
+type EstadoHilo struct {
+	types.TCB
+	Estado  string           `json:"estado"`            // NEW, READY, EXEC, BLOCKED o STOPPED
+	CPU     *int             `json:"cpu,omitempty"`     // Si está en EXEC, la CPU en la que ejecuta
+	Bloqueo *BloqueoConsulta `json:"bloqueo,omitempty"` // Si está en BLOCKED, por qué
+}
+
+// Detalle de un proceso en GET /processes/{pid}
+type DetalleProceso struct {
+	types.PCB
+	Estado string       `json:"estado"`
+	Hilos  []EstadoHilo `json:"hilos"`
+}
+
+// Un bloqueo con el motivo por nombre
+type BloqueoConsulta struct {
+	PID      uint32 `json:"pid"`
+	TID      uint32 `json:"tid"`
+	Motivo   string `json:"motivo"`
+	QuienFue string `json:"quien_fue"`
+}
+
+// Colas de estados en GET /queues
+type ColasConsulta struct {
+	New     []types.ProcesoNew        `json:"new"`
+	Ready   map[int][]types.PIDTID    `json:"ready"` // Por nivel, en el orden que los despacharía el algoritmo
+	Blocked []BloqueoConsulta         `json:"blocked"`
+	Stopped []types.PIDTID            `json:"stopped"`
+	Exit    []utils.Finalizacion      `json:"exit"`
+	Zombies []utils.Zombie            `json:"zombies"`
+	IO      map[string][]types.PIDTID `json:"io"` // Solicitudes esperando cada dispositivo
+}
+
+// Lo que ejecuta cada CPU en GET /execute
+type CPUConsulta struct {
+	CPU  int                  `json:"cpu"`
+	Ip   string               `json:"ip"`
+	Port int                  `json:"port"`
+	Hilo *utils.ExecuteActual `json:"hilo"` // nil si la CPU está libre
+}
+
+// Un mutex de un proceso en GET /mutexes
+type MutexConsulta struct {
+	PID       uint32   `json:"pid"`
+	Nombre    string   `json:"nombre"`
+	Duenio    string   `json:"duenio"` // TID que lo tiene o LIBRE
+	Esperando []uint32 `json:"esperando"`
+}
+
+// Arma la respuesta dentro de una consulta al núcleo y la manda como JSON. Si armar devuelve false responde 404
+func Responder_estado(w http.ResponseWriter, armar func() (any, bool)) {
+	var respuesta []byte
+	var err error
+	existe := false
+	planificador.Consultar(func() {
+		var datos any
+		if datos, existe = armar(); existe {
+			respuesta, err = json.Marshal(datos)
+		}
+	})
+
+	if !existe {
+		http.Error(w, "No encontrado", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Error al codificar el estado como JSON", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(respuesta)
+}
+
+// GET /processes
+func Consultar_procesos(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		Responder_estado(w, func() (any, bool) {
+			procesos := []ResumenProceso{}
+			for _, pcb := range utils.Estado.MapaPCB {
+				procesos = append(procesos, ResumenProceso{PID: pcb.PID, Padre: pcb.Padre, Estado: utils.Estado.Estado_de_proceso(pcb.PID), Hilos: len(pcb.TCBs), Creacion: pcb.Creacion})
+			}
+			sort.Slice(procesos, func(i, j int) bool { return procesos[i].PID < procesos[j].PID })
+			return procesos, true
+		})
+	}
+}
+
+// GET /processes/{pid}
+func Consultar_proceso(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		pid, err := strconv.ParseUint(r.PathValue("pid"), 10, 32)
+		if err != nil {
+			http.Error(w, "PID invalido", http.StatusBadRequest)
+			return
+		}
+
+		Responder_estado(w, func() (any, bool) {
+			pcb, existe := utils.Estado.MapaPCB[uint32(pid)]
+			if !existe {
+				return nil, false
+			}
+			detalle := DetalleProceso{PCB: pcb, Estado: utils.Estado.Estado_de_proceso(pcb.PID), Hilos: []EstadoHilo{}}
+			for _, tcb := range pcb.TCBs {
+				detalle.Hilos = append(detalle.Hilos, estadoDeHilo(tcb))
+			}
+			sort.Slice(detalle.Hilos, func(i, j int) bool { return detalle.Hilos[i].TID < detalle.Hilos[j].TID })
+			return detalle, true
+		})
+	}
+}
+
+// GET /queues
+func Consultar_colas(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		Responder_estado(w, func() (any, bool) {
+			colas := ColasConsulta{
+				New:     append([]types.ProcesoNew{}, utils.Estado.ColaNew...),
+				Ready:   make(map[int][]types.PIDTID),
+				Blocked: []BloqueoConsulta{},
+				Stopped: []types.PIDTID{},
+				Exit:    append([]utils.Finalizacion{}, utils.Estado.ColaExit...),
+				Zombies: []utils.Zombie{},
+				IO:      make(map[string][]types.PIDTID),
+			}
+			for nivel, cola := range planificador.Algoritmo.Listos() {
+				for _, tcb := range cola {
+					colas.Ready[nivel] = append(colas.Ready[nivel], types.PIDTID{PID: tcb.PID, TID: tcb.TID})
+				}
+			}
+			for _, bloqueado := range utils.Estado.ColaBlocked {
+				colas.Blocked = append(colas.Blocked, consultaDeBloqueo(bloqueado))
+			}
+			for _, tcb := range utils.Estado.ColaStopped {
+				colas.Stopped = append(colas.Stopped, types.PIDTID{PID: tcb.PID, TID: tcb.TID})
+			}
+			for _, zombie := range utils.Estado.Zombies {
+				colas.Zombies = append(colas.Zombies, zombie)
+			}
+			sort.Slice(colas.Zombies, func(i, j int) bool { return colas.Zombies[i].PID < colas.Zombies[j].PID })
+			for dispositivo, cola := range utils.Estado.ColasIO {
+				colas.IO[dispositivo] = []types.PIDTID{}
+				for _, solicitud := range cola {
+					colas.IO[dispositivo] = append(colas.IO[dispositivo], types.PIDTID{PID: solicitud.PID, TID: solicitud.TID})
+				}
+			}
+			return colas, true
+		})
+	}
+}
+
+// GET /execute
+func Consultar_ejecucion(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		Responder_estado(w, func() (any, bool) {
+			cpus := []CPUConsulta{}
+			for cpu, exec := range utils.Estado.Executes {
+				consulta := CPUConsulta{CPU: cpu, Ip: utils.Configs.CPUs[cpu].Ip, Port: utils.Configs.CPUs[cpu].Port}
+				if exec != nil {
+					copia := *exec
+					consulta.Hilo = &copia
+				}
+				cpus = append(cpus, consulta)
+			}
+			return cpus, true
+		})
+	}
+}
+
+// GET /mutexes
+func Consultar_mutexes(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		Responder_estado(w, func() (any, bool) {
+			mutexes := []MutexConsulta{}
+			for pid, pcb := range utils.Estado.MapaPCB {
+				for nombre, duenio := range pcb.Mutexs {
+					mutex := MutexConsulta{PID: pid, Nombre: nombre, Duenio: duenio, Esperando: []uint32{}}
+					for _, bloqueado := range utils.Bloqueados_por(utils.Estado.ColaBlocked, pid, utils.Mutex, nombre) {
+						mutex.Esperando = append(mutex.Esperando, bloqueado.TID)
+					}
+					mutexes = append(mutexes, mutex)
+				}
+			}
+			sort.Slice(mutexes, func(i, j int) bool {
+				if mutexes[i].PID != mutexes[j].PID {
+					return mutexes[i].PID < mutexes[j].PID
+				}
+				return mutexes[i].Nombre < mutexes[j].Nombre
+			})
+			return mutexes, true
+		})
+	}
+}
+
+// Busca en que estado está el hilo y, si ejecuta o está bloqueado, donde o por qué
+func estadoDeHilo(tcb types.TCB) EstadoHilo {
+	estado := EstadoHilo{TCB: tcb, Estado: utils.Estado.Estado_de_hilo(tcb.PID, tcb.TID)}
+	if exec := utils.Buscar_Execute(tcb.PID, tcb.TID); exec != nil {
+		cpu := exec.CPU
+		estado.CPU = &cpu
+	}
+	for _, bloqueado := range utils.Estado.ColaBlocked {
+		if bloqueado.PID == tcb.PID && bloqueado.TID == tcb.TID {
+			consulta := consultaDeBloqueo(bloqueado)
+			estado.Bloqueo = &consulta
+		}
+	}
+	return estado
+}
+
+func consultaDeBloqueo(bloqueado utils.Bloqueado) BloqueoConsulta {
+	return BloqueoConsulta{PID: bloqueado.PID, TID: bloqueado.TID, Motivo: bloqueado.Motivo.String(), QuienFue: bloqueado.QuienFue}
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/sisoputnfrba/tp-golang/cpu/cicloDeInstruccion"
+	"github.com/sisoputnfrba/tp-golang/kernel/client"
+	"github.com/sisoputnfrba/tp-golang/kernel/planificador"
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Syscalls de memoria compartida. Los segmentos los administra memoria; el kernel solo le pasa el pedido con el PID
+// del hilo que hizo la syscall
+
+// Crea el segmento en memoria. Responde "SHM_YA_EXISTE" o "SIN_ESPACIO" si memoria no lo pudo crear
+func SHM_CREATE(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "SHM_CREATE", logger)
+		if !ok {
+			return
+		}
+
+		var segmento cicloDeInstruccion.EstructuraSegmento
+		err := json.NewDecoder(r.Body).Decode(&segmento)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		pedido := types.PedidoSHM{PID: exec.PID, Nombre: segmento.Nombre, Tamanio: segmento.Tamanio}
+		switch client.Enviar_Body_Con_Respuesta[types.PedidoSHM, struct{}](pedido, utils.Configs.IpMemory, utils.Configs.PortMemory, "SHM_CREATE", nil, logger) {
+		case http.StatusOK:
+			Responder_JSON(w, http.StatusOK, "OK")
+		case http.StatusConflict:
+			Responder_JSON(w, http.StatusOK, "SHM_YA_EXISTE")
+		default:
+			Responder_JSON(w, http.StatusOK, "SIN_ESPACIO")
+		}
+	}
+}
+
+// Adjunta el segmento al proceso y responde 202 con su dirección lógica para que la CPU la pueda traducir.
+// Si el segmento no existe finaliza el hilo y responde con "HILO_FINALIZADO"
+func SHM_ATTACH(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "SHM_ATTACH", logger)
+		if !ok {
+			return
+		}
+
+		var segmento cicloDeInstruccion.EstructuraSegmento
+		err := json.NewDecoder(r.Body).Decode(&segmento)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		var adjunto types.SegmentoAdjunto
+		pedido := types.PedidoSHM{PID: exec.PID, Nombre: segmento.Nombre}
+		if client.Enviar_Body_Con_Respuesta(pedido, utils.Configs.IpMemory, utils.Configs.PortMemory, "SHM_ATTACH", &adjunto, logger) != http.StatusOK {
+			logger.Info(fmt.Sprintf("## (%d:%d) - No existe el segmento compartido %s", exec.PID, exec.TID, segmento.Nombre))
+			if !Aplicar_syscall(w, planificador.EventosExit, exec, func() {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+			}, logger) {
+				return
+			}
+			Responder_JSON(w, http.StatusOK, "HILO_FINALIZADO")
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(adjunto)
+	}
+}
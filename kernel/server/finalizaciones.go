@@ -0,0 +1,43 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+)
+
+// Como terminaron un proceso y sus hilos
+type RegistroFinalizacion struct {
+	PID     uint32               `json:"pid"`
+	Proceso *utils.Finalizacion  `json:"proceso"` // nil si el proceso sigue vivo
+	Hilos   []utils.Finalizacion `json:"hilos"`   // Hilos del proceso que ya finalizaron
+}
+
+// Devuelve como terminó el proceso y cada uno de sus hilos finalizados. Responde 404 si no existe ni existió
+func Consultar_finalizacion(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		pid, err := strconv.ParseUint(r.PathValue("pid"), 10, 32)
+		if err != nil {
+			http.Error(w, "PID invalido", http.StatusBadRequest)
+			return
+		}
+
+		Responder_estado(w, func() (any, bool) {
+			registro := RegistroFinalizacion{PID: uint32(pid), Hilos: []utils.Finalizacion{}}
+			_, existe := utils.Estado.MapaPCB[registro.PID]
+			if fin, finalizado := utils.Estado.Finalizados[registro.PID]; finalizado {
+				registro.Proceso = &fin
+				existe = true
+			}
+			for _, hilo := range utils.Estado.ColaExit {
+				if hilo.PID == registro.PID {
+					registro.Hilos = append(registro.Hilos, hilo)
+				}
+			}
+			return registro, existe
+		})
+	}
+}
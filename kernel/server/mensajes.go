@@ -0,0 +1,144 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/sisoputnfrba/tp-golang/cpu/cicloDeInstruccion"
+	"github.com/sisoputnfrba/tp-golang/kernel/planificador"
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+)
+
+// Syscalls de las colas de mensajes entre procesos (ver planificador/mensajes.go)
+
+// Abre la cola de mensajes, creándola si no existe
+func MQ_OPEN(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "MQ_OPEN", logger)
+		if !ok {
+			return
+		}
+
+		var mensaje cicloDeInstruccion.EstructuraMensaje
+		err := json.NewDecoder(r.Body).Decode(&mensaje)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		if !Aplicar_syscall(w, planificador.EventosSyscall, exec, func() {
+			if _, existe := utils.Estado.ColasMQ[mensaje.Cola]; !existe {
+				utils.Estado.ColasMQ[mensaje.Cola] = []uint32{}
+				logger.Info(fmt.Sprintf("## Se crea la cola de mensajes %s", mensaje.Cola))
+			}
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, http.StatusOK, "OK")
+	}
+}
+
+// 3 CASOS:
+// 1. Si la cola no existe, finaliza el hilo y responde con "HILO_FINALIZADO"
+// 2. Si hay lugar, encola el valor, despierta a un hilo que espere mensajes y responde con "MENSAJE_ENVIADO"
+// 3. Si la cola está llena, bloquea el hilo y responde con "HILO_BLOQUEADO"
+func MQ_SEND(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "MQ_SEND", logger)
+		if !ok {
+			return
+		}
+
+		var mensaje cicloDeInstruccion.EstructuraMensaje
+		err := json.NewDecoder(r.Body).Decode(&mensaje)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		var respuesta string
+		estado := http.StatusOK
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			cola, existe := utils.Estado.ColasMQ[mensaje.Cola]
+			if !existe {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+
+			if len(cola) >= utils.Configs.CapacidadMQ {
+				if _, bloqueado := planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.MQLlena, QuienFue: mensaje.Cola}, logger); !bloqueado {
+					respuesta = "HILO_FINALIZADO"
+					return
+				}
+				respuesta = "HILO_BLOQUEADO"
+				return
+			}
+
+			utils.Estado.ColasMQ[mensaje.Cola] = append(cola, mensaje.Valor)
+			planificador.Despertar_de_cola_de_mensajes(mensaje.Cola, utils.MQVacia, logger)
+			respuesta, estado = "MENSAJE_ENVIADO", http.StatusAccepted
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
+
+// 3 CASOS:
+// 1. Si la cola no existe, finaliza el hilo y responde con "HILO_FINALIZADO"
+// 2. Si hay mensajes, saca el primero, despierta a un hilo que espere lugar y responde 202 con el mensaje
+// 3. Si la cola está vacía, bloquea el hilo y responde con "HILO_BLOQUEADO"
+func MQ_RECV(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "MQ_RECV", logger)
+		if !ok {
+			return
+		}
+
+		var mensaje cicloDeInstruccion.EstructuraMensaje
+		err := json.NewDecoder(r.Body).Decode(&mensaje)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		var respuesta string
+		recibido := false
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			cola, existe := utils.Estado.ColasMQ[mensaje.Cola]
+			if !existe {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+
+			if len(cola) == 0 {
+				if _, bloqueado := planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.MQVacia, QuienFue: mensaje.Cola}, logger); !bloqueado {
+					respuesta = "HILO_FINALIZADO"
+					return
+				}
+				respuesta = "HILO_BLOQUEADO"
+				return
+			}
+
+			mensaje.Valor = cola[0]
+			utils.Estado.ColasMQ[mensaje.Cola] = cola[1:]
+			planificador.Despertar_de_cola_de_mensajes(mensaje.Cola, utils.MQLlena, logger)
+			recibido = true
+		}, logger) {
+			return
+		}
+
+		if !recibido {
+			Responder_JSON(w, http.StatusOK, respuesta)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(mensaje)
+	}
+}
@@ -0,0 +1,139 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/sisoputnfrba/tp-golang/cpu/cicloDeInstruccion"
+	"github.com/sisoputnfrba/tp-golang/kernel/planificador"
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+)
+
+// Syscalls de los recursos que administra el algoritmo del banquero (ver planificador/banquero.go).
+// Los errores del programa (recurso que no está en el config, pedir más de lo declarado, liberar lo que no tiene)
+// finalizan el hilo, igual que usar un mutex que no existe
+
+// Declara el máximo de instancias del recurso que puede llegar a usar el proceso.
+// Si con el nuevo máximo el estado deja de ser seguro el reclamo no se acepta y el hilo finaliza
+func RESOURCE_DECLARE(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "RESOURCE_DECLARE", logger)
+		if !ok {
+			return
+		}
+
+		var pedido cicloDeInstruccion.EstructuraPedidoRecurso
+		err := json.NewDecoder(r.Body).Decode(&pedido)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		respuesta := "RECLAMO_DECLARADO"
+		estado := http.StatusAccepted
+		if !Aplicar_syscall(w, planificador.EventosExit, exec, func() {
+			pcb := utils.Estado.MapaPCB[exec.PID]
+			total, existe := utils.Configs.Recursos[pedido.Recurso]
+			if !existe || pedido.Cantidad > total || pedido.Cantidad < pcb.Asignados[pedido.Recurso] {
+				logger.Info(fmt.Sprintf("## (%d:%d) - Reclamo inválido de %d instancias de %s", exec.PID, exec.TID, pedido.Cantidad, pedido.Recurso))
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta, estado = "HILO_FINALIZADO", http.StatusOK
+				return
+			}
+			if !planificador.Declarar_reclamo(exec.PID, pedido.Recurso, pedido.Cantidad) {
+				logger.Info(fmt.Sprintf("## (%d:%d) - Reclamo de %d instancias de %s deja el estado inseguro", exec.PID, exec.TID, pedido.Cantidad, pedido.Recurso))
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta, estado = "HILO_FINALIZADO", http.StatusOK
+			}
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
+
+// 3 CASOS:
+// 1. Si el pedido supera lo que le falta para su máximo declarado, finaliza el hilo y responde con "HILO_FINALIZADO"
+// 2. Si hay instancias y el estado queda seguro, se las asigna y responde con "RECURSO_ASIGNADO"
+// 3. Si no, bloquea el hilo hasta que se pueda conceder y responde con "HILO_BLOQUEADO"
+func RESOURCE_REQUEST(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "RESOURCE_REQUEST", logger)
+		if !ok {
+			return
+		}
+
+		var pedido cicloDeInstruccion.EstructuraPedidoRecurso
+		err := json.NewDecoder(r.Body).Decode(&pedido)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		var respuesta string
+		estado := http.StatusOK
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			pcb := utils.Estado.MapaPCB[exec.PID]
+			if pedido.Cantidad <= 0 || pedido.Cantidad > pcb.Reclamos[pedido.Recurso]-pcb.Asignados[pedido.Recurso] {
+				logger.Info(fmt.Sprintf("## (%d:%d) - Pidió %d instancias de %s y supera su máximo declarado", exec.PID, exec.TID, pedido.Cantidad, pedido.Recurso))
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+
+			if planificador.Conceder_recurso(exec.PID, pedido.Recurso, pedido.Cantidad) {
+				respuesta, estado = "RECURSO_ASIGNADO", http.StatusAccepted
+				return
+			}
+
+			if _, bloqueado := planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.Recurso, QuienFue: pedido.Recurso, Cantidad: pedido.Cantidad}, logger); !bloqueado {
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+			respuesta = "HILO_BLOQUEADO"
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
+
+// Devuelve instancias del recurso y reintenta los pedidos bloqueados. El hilo sigue ejecutando
+// Si libera más de lo que tiene el proceso responde con "HILO_FINALIZADO" y finaliza el hilo
+func RESOURCE_RELEASE(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "RESOURCE_RELEASE", logger)
+		if !ok {
+			return
+		}
+
+		var pedido cicloDeInstruccion.EstructuraPedidoRecurso
+		err := json.NewDecoder(r.Body).Decode(&pedido)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		respuesta := "RECURSO_LIBERADO"
+		estado := http.StatusAccepted
+		if !Aplicar_syscall(w, planificador.EventosReady, exec, func() {
+			asignados := utils.Estado.MapaPCB[exec.PID].Asignados
+			if pedido.Cantidad <= 0 || pedido.Cantidad > asignados[pedido.Recurso] {
+				logger.Info(fmt.Sprintf("## (%d:%d) - Liberó %d instancias de %s y no las tenía", exec.PID, exec.TID, pedido.Cantidad, pedido.Recurso))
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta, estado = "HILO_FINALIZADO", http.StatusOK
+				return
+			}
+			asignados[pedido.Recurso] -= pedido.Cantidad
+			planificador.Reintentar_pedidos_de_recursos(logger)
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
@@ -0,0 +1,80 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/sisoputnfrba/tp-golang/cpu/cicloDeInstruccion"
+	"github.com/sisoputnfrba/tp-golang/kernel/planificador"
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+)
+
+// Señales: las pueden mandar los hilos con una syscall o un operador con los endpoints de administración.
+// En los dos casos la señal queda pendiente y la entrega el núcleo cuando el hilo afectado no está ejecutando
+
+// PROCESS_KILL, THREAD_KILL, PROCESS_STOP y PROCESS_CONT como syscall. El hilo que la manda sigue ejecutando
+// (si la señal lo afecta a él, lo saca la interrupción). Responde "NO_EXISTE" si no existe el proceso o el hilo
+func Syscall_senial(tipo string, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, tipo, logger)
+		if !ok {
+			return
+		}
+
+		var params cicloDeInstruccion.EstructuraSenial
+		err := json.NewDecoder(r.Body).Decode(&params)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		enviada := false
+		if !Aplicar_syscall(w, planificador.EventosSyscall, exec, func() {
+			enviada = planificador.Enviar_senial(utils.Senial{Tipo: tipo, PID: params.PID, TID: params.TID}, logger)
+		}, logger) {
+			return
+		}
+
+		if !enviada {
+			logger.Info(fmt.Sprintf("## (%d:%d) - %s a un proceso o hilo que no existe", exec.PID, exec.TID, tipo))
+			Responder_JSON(w, http.StatusOK, "NO_EXISTE")
+			return
+		}
+		Responder_JSON(w, http.StatusOK, "OK")
+	}
+}
+
+// Las mismas señales mandadas por un operador; el PID y el TID van en la ruta. Responde 404 si no existen
+func Admin_senial(tipo string, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		pid, err := strconv.ParseUint(r.PathValue("pid"), 10, 32)
+		if err != nil {
+			http.Error(w, "PID invalido", http.StatusBadRequest)
+			return
+		}
+		var tid uint64
+		if tipo == "THREAD_KILL" {
+			tid, err = strconv.ParseUint(r.PathValue("tid"), 10, 32)
+			if err != nil {
+				http.Error(w, "TID invalido", http.StatusBadRequest)
+				return
+			}
+		}
+
+		enviada := false
+		planificador.Notificar(planificador.EventosSyscall, func() {
+			enviada = planificador.Enviar_senial(utils.Senial{Tipo: tipo, PID: uint32(pid), TID: uint32(tid)}, logger)
+		})
+
+		if !enviada {
+			Responder_JSON(w, http.StatusNotFound, "NO_EXISTE")
+			return
+		}
+		logger.Info(fmt.Sprintf("## (%d:%d) - %s enviada por el operador", pid, tid, tipo))
+		Responder_JSON(w, http.StatusOK, "OK")
+	}
+}
@@ -22,6 +22,7 @@ func Iniciar_kernel(logger *slog.Logger) {
 	// Endpoints
 	mux.HandleFunc("POST /PROCESS_CREATE", PROCESS_CREATE(logger))
 	mux.HandleFunc("POST /PROCESS_EXIT", PROCESS_EXIT(logger))
+	mux.HandleFunc("POST /PROCESS_WAIT", PROCESS_WAIT(logger))
 	mux.HandleFunc("POST /THREAD_CREATE", THREAD_CREATE(logger))
 	mux.HandleFunc("POST /THREAD_JOIN", THREAD_JOIN(logger))
 	mux.HandleFunc("POST /THREAD_CANCEL", THREAD_CANCEL(logger))
@@ -31,19 +32,129 @@ func Iniciar_kernel(logger *slog.Logger) {
 	mux.HandleFunc("POST /MUTEX_CREATE", MUTEX_CREATE(logger))
 	mux.HandleFunc("POST /MUTEX_LOCK", MUTEX_LOCK(logger))
 	mux.HandleFunc("POST /MUTEX_UNLOCK", MUTEX_UNLOCK(logger))
+	mux.HandleFunc("POST /SEM_CREATE", SEM_CREATE(logger))
+	mux.HandleFunc("POST /SEM_WAIT", SEM_WAIT(logger))
+	mux.HandleFunc("POST /SEM_POST", SEM_POST(logger))
+	mux.HandleFunc("POST /COND_CREATE", COND_CREATE(logger))
+	mux.HandleFunc("POST /COND_WAIT", COND_WAIT(logger))
+	mux.HandleFunc("POST /COND_SIGNAL", COND_SIGNAL(logger))
+	mux.HandleFunc("POST /COND_BROADCAST", COND_BROADCAST(logger))
+	mux.HandleFunc("POST /RWLOCK_CREATE", RWLOCK_CREATE(logger))
+	mux.HandleFunc("POST /RWLOCK_RDLOCK", RWLOCK_RDLOCK(logger))
+	mux.HandleFunc("POST /RWLOCK_WRLOCK", RWLOCK_WRLOCK(logger))
+	mux.HandleFunc("POST /RWLOCK_UNLOCK", RWLOCK_UNLOCK(logger))
+	mux.HandleFunc("POST /BARRIER_CREATE", BARRIER_CREATE(logger))
+	mux.HandleFunc("POST /BARRIER_WAIT", BARRIER_WAIT(logger))
+	mux.HandleFunc("POST /RESOURCE_DECLARE", RESOURCE_DECLARE(logger))
+	mux.HandleFunc("POST /RESOURCE_REQUEST", RESOURCE_REQUEST(logger))
+	mux.HandleFunc("POST /RESOURCE_RELEASE", RESOURCE_RELEASE(logger))
+	mux.HandleFunc("POST /MQ_OPEN", MQ_OPEN(logger))
+	mux.HandleFunc("POST /MQ_SEND", MQ_SEND(logger))
+	mux.HandleFunc("POST /MQ_RECV", MQ_RECV(logger))
+	mux.HandleFunc("POST /SHM_CREATE", SHM_CREATE(logger))
+	mux.HandleFunc("POST /SHM_ATTACH", SHM_ATTACH(logger))
+	mux.HandleFunc("POST /PROCESS_KILL", Syscall_senial("PROCESS_KILL", logger))
+	mux.HandleFunc("POST /THREAD_KILL", Syscall_senial("THREAD_KILL", logger))
+	mux.HandleFunc("POST /PROCESS_STOP", Syscall_senial("PROCESS_STOP", logger))
+	mux.HandleFunc("POST /PROCESS_CONT", Syscall_senial("PROCESS_CONT", logger))
 	mux.HandleFunc("POST /IO", IO(logger))
+	mux.HandleFunc("POST /SET_TICKETS", SET_TICKETS(logger))
+	mux.HandleFunc("POST /THREAD_SET_RT", THREAD_SET_RT(logger))
+	mux.HandleFunc("POST /THREAD_JOB_END", THREAD_JOB_END(logger))
 
 	mux.HandleFunc("POST /recibir-desalojo", Recibir_desalojo(logger))
 
+	// Endpoints de administración
+	mux.HandleFunc("POST /processes/{pid}/kill", Admin_senial("PROCESS_KILL", logger))
+	mux.HandleFunc("POST /processes/{pid}/threads/{tid}/kill", Admin_senial("THREAD_KILL", logger))
+	mux.HandleFunc("POST /processes/{pid}/stop", Admin_senial("PROCESS_STOP", logger))
+	mux.HandleFunc("POST /processes/{pid}/cont", Admin_senial("PROCESS_CONT", logger))
+	mux.HandleFunc("GET /terminations/{pid}", Consultar_finalizacion(logger))
+	mux.HandleFunc("GET /processes", Consultar_procesos(logger))
+	mux.HandleFunc("GET /processes/{pid}", Consultar_proceso(logger))
+	mux.HandleFunc("GET /queues", Consultar_colas(logger))
+	mux.HandleFunc("GET /execute", Consultar_ejecucion(logger))
+	mux.HandleFunc("GET /mutexes", Consultar_mutexes(logger))
+
 	conexiones.LevantarServidor(strconv.Itoa(utils.Configs.Port), mux, logger)
 
 }
 
+// Identifica el hilo que hizo la syscall (la CPU manda el PID y TID que está ejecutando en los headers) y loguea la syscall.
+// Si no se lo puede identificar responde con error y devuelve false
+func Recibir_syscall(w http.ResponseWriter, r *http.Request, syscall string, logger *slog.Logger) (*utils.ExecuteActual, bool) {
+	var exec *utils.ExecuteActual
+	planificador.Notificar(planificador.EventosSyscall, func() {
+		exec = Hilo_llamador(r)
+	})
+	if exec == nil {
+		logger.Error(fmt.Sprintf("No se encontró en ninguna CPU el hilo que solicitó la syscall %s", syscall))
+		http.Error(w, "Hilo no encontrado en ninguna CPU", http.StatusBadRequest)
+		return nil, false
+	}
+	logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: %s", exec.PID, exec.TID, syscall))
+	return exec, true
+}
+
+// Aplica la syscall en un evento del núcleo solo si el hilo que la hizo sigue ejecutando. Recibir_syscall lo identifica en un
+// evento anterior, y en el medio lo pueden haber finalizado (PROCESS_KILL, un deadlock, otro hilo que terminó el proceso):
+// en ese caso no se aplica, se libera la CPU que esperaba el desalojo y se responde HILO_FINALIZADO para que la CPU corte el ciclo.
+// Retorna false si no se aplicó (ya se respondió)
+func Aplicar_syscall(w http.ResponseWriter, canal chan planificador.Evento, exec *utils.ExecuteActual, aplicar func(), logger *slog.Logger) bool {
+	aplicada := false
+	planificador.Notificar(canal, func() {
+		if utils.Estado.Executes[exec.CPU] != exec {
+			return
+		}
+		if _, existe := utils.Estado.MapaPCB[exec.PID].TCBs[exec.TID]; !existe {
+			utils.Estado.Liberar_CPU(exec)
+			return
+		}
+		aplicar()
+		aplicada = true
+	})
+	if !aplicada {
+		logger.Info(fmt.Sprintf("## (%d:%d) - El hilo finalizó antes de atender la syscall", exec.PID, exec.TID))
+		Responder_JSON(w, http.StatusOK, "HILO_FINALIZADO")
+	}
+	return aplicada
+}
+
+// Devuelve donde está ejecutando el hilo que hizo la petición, nil si no se lo encuentra (se llama dentro de un evento del núcleo)
+func Hilo_llamador(r *http.Request) *utils.ExecuteActual {
+	pid, errPID := strconv.ParseUint(r.Header.Get("PID"), 10, 32)
+	tid, errTID := strconv.ParseUint(r.Header.Get("TID"), 10, 32)
+	if errPID == nil && errTID == nil {
+		return utils.Buscar_Execute(uint32(pid), uint32(tid))
+	}
+
+	// Sin headers solo se lo puede identificar si hay una única CPU
+	if len(utils.Estado.Executes) == 1 {
+		return utils.Estado.Executes[0]
+	}
+	return nil
+}
+
+// Responde con el mensaje codificado como JSON
+func Responder_JSON(w http.ResponseWriter, estado int, mensaje string) {
+	respuesta, err := json.Marshal(mensaje)
+	if err != nil {
+		http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(estado)
+	w.Write(respuesta)
+}
+
 // Syscalls referidas a procesos
 
+// Crea el proceso como hijo del que hace la syscall y responde 202 con su PID (el hilo sigue ejecutando)
 func PROCESS_CREATE(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: PROCESS_CREATE", utils.Execute.PID, utils.Execute.TID))
+		exec, ok := Recibir_syscall(w, r, "PROCESS_CREATE", logger)
+		if !ok {
+			return
+		}
 		decoder := json.NewDecoder(r.Body)
 		var magic types.ProcessCreateParams
 		err := decoder.Decode(&magic)
@@ -53,53 +164,114 @@ func PROCESS_CREATE(logger *slog.Logger) http.HandlerFunc {
 			w.Write([]byte("Error al decodificar mensaje"))
 			return
 		}
-		planificador.Crear_proceso(magic.Path, magic.Tamanio, magic.Prioridad, logger)
+		hijo := cicloDeInstruccion.EstructuraProceso{}
+		if !Aplicar_syscall(w, planificador.EventosReady, exec, func() {
+			hijo.PID = planificador.Crear_proceso(magic.Path, magic.Tamanio, magic.Prioridad, exec.PID, logger)
+		}, logger) {
+			return
+		}
 
-		w.WriteHeader(http.StatusOK)
-		w.Write([]byte("OK"))
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(hijo)
 	}
 }
 
-func Colas_vacias(colas map[int][]types.TCB) bool {
-	for _, cola := range colas {
-		if len(cola) != 0 {
-			return false
+// Si el hijo ya finalizó lo saca de los zombies y responde 202 con su estado de salida; si sigue vivo bloquea al hilo
+// hasta que finalice (al despertarse repite PROCESS_WAIT). Si no es hijo del proceso finaliza el hilo
+func PROCESS_WAIT(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		exec, ok := Recibir_syscall(w, r, "PROCESS_WAIT", logger)
+		if !ok {
+			return
+		}
+
+		var hijo cicloDeInstruccion.EstructuraProceso
+		err := json.NewDecoder(r.Body).Decode(&hijo)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
 		}
+
+		var respuesta string
+		terminado := false
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			if zombie, existe := planificador.Cosechar_hijo(exec.PID, hijo.PID, logger); existe {
+				hijo.Estado = zombie.Estado
+				terminado = true
+				return
+			}
+
+			if !planificador.Es_hijo(exec.PID, hijo.PID) {
+				logger.Info(fmt.Sprintf("## (%d:%d) - El proceso %d no es hijo del proceso", exec.PID, exec.TID, hijo.PID))
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+
+			if _, bloqueado := planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.Espera, QuienFue: strconv.Itoa(int(hijo.PID))}, logger); !bloqueado {
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+			respuesta = "HILO_BLOQUEADO"
+		}, logger) {
+			return
+		}
+
+		if !terminado {
+			Responder_JSON(w, http.StatusOK, respuesta)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		json.NewEncoder(w).Encode(hijo)
 	}
-	return true
 }
 
+// El body trae el código de salida (0 si la instrucción no lo indica)
 func PROCESS_EXIT(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: PROCESS_EXIT", utils.Execute.PID, utils.Execute.TID))
-		finaliza := utils.Execute.PID
+		exec, ok := Recibir_syscall(w, r, "PROCESS_EXIT", logger)
+		if !ok {
+			return
+		}
+		finaliza := exec.PID
+
+		var salida cicloDeInstruccion.EstructuraSalida
+		err := json.NewDecoder(r.Body).Decode(&salida)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
 
-		utils.Execute = nil
-		planificador.Finalizar_proceso(finaliza, logger)
+		if !Aplicar_syscall(w, planificador.EventosExit, exec, func() {
+			planificador.Terminar_rafaga(exec, false)
+			planificador.Finalizar_proceso(finaliza, utils.Causa{Motivo: "PROCESS_EXIT", Codigo: salida.Codigo}, logger)
+		}, logger) {
+			return
+		}
 
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
-
-		if !Colas_vacias(planificador.ColaReady) {
-			planificador.SignalEnviado = true
-			planificador.Semaforo.Signal()
-		}
 	}
 }
 
 func DUMP_MEMORY(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: DUMP_MEMORY", utils.Execute.PID, utils.Execute.TID))
-		parametros := types.PIDTID{TID: utils.Execute.TID, PID: utils.Execute.PID} // Saco el pid y el tid del hilo que esta ejecutando
-
-		bloqueado := utils.Bloqueado{PID: parametros.PID, TID: parametros.TID, Motivo: utils.DUMP}
-		logger.Info(fmt.Sprintf("## (%d:%d) - Bloqueado por: DUMP MEMORY", utils.Execute.PID, utils.Execute.TID))
-		utils.Execute = nil
-		utils.Encolar(&planificador.ColaBlocked, bloqueado)
+		exec, ok := Recibir_syscall(w, r, "DUMP_MEMORY", logger)
+		if !ok {
+			return
+		}
+		parametros := types.PIDTID{TID: exec.TID, PID: exec.PID} // Saco el pid y el tid del hilo que esta ejecutando
 
-		planificador.SignalEnviado = true
-		planificador.Semaforo.Signal()
+		bloqueado := false
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			_, bloqueado = planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: parametros.PID, TID: parametros.TID, Motivo: utils.DUMP}, logger)
+		}, logger) {
+			return
+		}
+		if !bloqueado {
+			Responder_JSON(w, http.StatusOK, "HILO_FINALIZADO")
+			return
+		}
 
+		// Memoria responde el dump en dump_response antes de contestar este pedido, asi que no se lo puede hacer dentro del núcleo
 		client.Enviar_Body(parametros, utils.Configs.IpMemory, utils.Configs.PortMemory, "MEMORY-DUMP", logger)
 
 		w.WriteHeader(http.StatusOK)
@@ -114,23 +286,26 @@ func Respuesta_dump(logger *slog.Logger) http.HandlerFunc {
 		if err != nil {
 			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s", err.Error()))
 			w.WriteHeader(http.StatusBadRequest)
+			return
 		}
 
-		if respuestaDelDump.Respuesta == "OK" {
-			desbloqueado := utils.Desencolar_Por_Motivo(&planificador.ColaBlocked, utils.DUMP)
-			pcb := utils.Obtener_PCB_por_PID(desbloqueado.PID)
-			tcb := pcb.TCBs[desbloqueado.TID]
-			utils.Encolar_ColaReady(planificador.ColaReady, tcb)
-
-			planificador.SignalEnviado = true
-			planificador.Semaforo.Signal()
-
-		} else {
-			desbloqueado := utils.Desencolar_Por_Motivo(&planificador.ColaBlocked, utils.DUMP)
-			pcb := utils.Obtener_PCB_por_PID(desbloqueado.PID)
-			tcb := pcb.TCBs[desbloqueado.TID]
-			planificador.Finalizar_proceso(tcb.PID, logger)
+		canal := planificador.EventosReady
+		if respuestaDelDump.Respuesta != "OK" {
+			canal = planificador.EventosExit
 		}
+
+		planificador.Notificar(canal, func() {
+			// Desbloqueamos al hilo que pidió este dump
+			bloqueado, existe := utils.Buscar_bloqueo(utils.Estado.ColaBlocked, respuestaDelDump.PID, respuestaDelDump.TID, utils.DUMP)
+			if !existe {
+				return
+			}
+			if respuestaDelDump.Respuesta == "OK" {
+				planificador.Desbloquear_hilo(bloqueado.ID)
+			} else {
+				planificador.Finalizar_proceso(bloqueado.PID, utils.FIN_DUMP_FALLIDO, logger)
+			}
+		})
 	}
 }
 
@@ -139,7 +314,10 @@ func Respuesta_dump(logger *slog.Logger) http.HandlerFunc {
 // BODY - VERBO POST
 func THREAD_CREATE(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: THREAD_CREATE", utils.Execute.PID, utils.Execute.TID))
+		exec, ok := Recibir_syscall(w, r, "THREAD_CREATE", logger)
+		if !ok {
+			return
+		}
 
 		// Agarramos los parametros del body
 		var params types.ThreadCreateParams
@@ -151,50 +329,52 @@ func THREAD_CREATE(logger *slog.Logger) http.HandlerFunc {
 		}
 
 		// Creamos el hilo
-		planificador.Crear_hilo(params.Path, params.Prioridad, logger)
-
-		// Respondemos con un OK
-		respuesta, err := json.Marshal("OK")
-		if err != nil {
-			http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
-		}
-
-		if utils.Configs.SchedulerAlgorithm != "FIFO" {
-			planificador.SignalEnviado = true
-			planificador.Semaforo.Signal()
+		if !Aplicar_syscall(w, planificador.EventosReady, exec, func() {
+			planificador.Crear_hilo(exec.PID, params.Path, params.Prioridad, logger)
+		}, logger) {
+			return
 		}
 
-		w.WriteHeader(http.StatusOK)
-		w.Write(respuesta)
+		// Respondemos con un OK
+		Responder_JSON(w, http.StatusOK, "OK")
 	}
 }
 
+// El body trae el código de salida (0 si la instrucción no lo indica)
 func THREAD_EXIT(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: THREAD_EXIT", utils.Execute.PID, utils.Execute.TID))
-
-		// Finalizamos el hilo
-		planificador.Finalizar_hilo(utils.Execute.TID, utils.Execute.PID, logger)
+		exec, ok := Recibir_syscall(w, r, "THREAD_EXIT", logger)
+		if !ok {
+			return
+		}
 
-		// Respondemos con un OK
-		respuesta, err := json.Marshal("OK")
+		var salida cicloDeInstruccion.EstructuraSalida
+		err := json.NewDecoder(r.Body).Decode(&salida)
 		if err != nil {
-			http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
 		}
-		w.WriteHeader(http.StatusOK)
-		w.Write(respuesta)
 
-		utils.Execute = nil
-		planificador.SignalEnviado = true
-		planificador.Semaforo.Signal()
+		// Liberamos la CPU y finalizamos el hilo
+		if !Aplicar_syscall(w, planificador.EventosExit, exec, func() {
+			planificador.Terminar_rafaga(exec, false)
+			planificador.Finalizar_hilo(exec.TID, exec.PID, utils.Causa{Motivo: "THREAD_EXIT", Codigo: salida.Codigo}, logger)
+		}, logger) {
+			return
+		}
+
+		// Respondemos con un OK
+		Responder_JSON(w, http.StatusOK, "OK")
 	}
 }
 
 func THREAD_CANCEL(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: THREAD_CANCEL", utils.Execute.PID, utils.Execute.TID))
+		exec, ok := Recibir_syscall(w, r, "THREAD_CANCEL", logger)
+		if !ok {
+			return
+		}
 
 		// Totamos el valor del body
 		var tid cicloDeInstruccion.EstructuraTid
@@ -203,27 +383,35 @@ func THREAD_CANCEL(logger *slog.Logger) http.HandlerFunc {
 			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
 		}
 
-		// Finalizamos el hilo
-		_, existe := utils.MapaPCB[utils.Execute.PID].TCBs[uint32(tid.TID)]
-		if existe {
-			planificador.Finalizar_hilo(uint32(tid.TID), utils.Execute.PID, logger)
+		// Finalizamos el hilo; si es el mismo que hizo la syscall deja la CPU, sino sigue ejecutando
+		propio := uint32(tid.TID) == exec.TID
+		if !Aplicar_syscall(w, planificador.EventosExit, exec, func() {
+			_, existe := utils.Estado.MapaPCB[exec.PID].TCBs[uint32(tid.TID)]
+			if !existe {
+				return
+			}
+			if propio {
+				planificador.Terminar_rafaga(exec, false)
+			}
+			planificador.Finalizar_hilo(uint32(tid.TID), exec.PID, utils.FIN_THREAD_CANCEL, logger)
+		}, logger) {
+			return
 		}
 
-		// Respondemos con un OK
-		respuesta, err := json.Marshal("OK")
-		if err != nil {
-			http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
+		if propio {
+			Responder_JSON(w, http.StatusOK, "HILO_FINALIZADO")
+			return
 		}
-
-		w.WriteHeader(http.StatusOK)
-		w.Write(respuesta)
-		client.Enviar_Body(types.PIDTID{TID: utils.Execute.TID, PID: utils.Execute.PID}, utils.Configs.IpCPU, utils.Configs.PortCPU, "EJECUTAR_KERNEL", logger)
+		Responder_JSON(w, http.StatusAccepted, "CONTINUAR_EJECUCION")
 	}
 }
 
 func THREAD_JOIN(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: THREAD_JOIN", utils.Execute.PID, utils.Execute.TID))
+		exec, ok := Recibir_syscall(w, r, "THREAD_JOIN", logger)
+		if !ok {
+			return
+		}
 
 		// Tomamos el valor del body
 		var tid cicloDeInstruccion.EstructuraTid
@@ -232,49 +420,33 @@ func THREAD_JOIN(logger *slog.Logger) http.HandlerFunc {
 			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
 		}
 
-		_, existe := utils.MapaPCB[utils.Execute.PID].TCBs[uint32(tid.TID)]
+		respuesta, estado := "OK", http.StatusOK
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			_, existe := utils.Estado.MapaPCB[exec.PID].TCBs[uint32(tid.TID)]
+			if !existe {
+				respuesta, estado = "CONTINUAR_EJECUCION", http.StatusAccepted
+				return
+			}
 
-		if !existe {
-			respuesta, err := json.Marshal("CONTINUAR_EJECUCION")
-			if err != nil {
-				http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
+			// Mandamos el hilo a block
+			if _, bloqueado := planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.THREAD_JOIN, QuienFue: strconv.Itoa(int(tid.TID))}, logger); !bloqueado {
+				respuesta = "HILO_FINALIZADO"
 			}
-			w.WriteHeader(http.StatusAccepted)
-			w.Write(respuesta)
+		}, logger) {
 			return
 		}
 
-		// Mandamos el hilo a block
-		bloqueado := utils.Bloqueado{PID: utils.Execute.PID, TID: utils.Execute.TID, Motivo: utils.THREAD_JOIN, QuienFue: strconv.Itoa(int(tid.TID))}
-
-		utils.Encolar(&planificador.ColaBlocked, bloqueado)
-
-		if utils.Configs.SchedulerAlgorithm == "FIFO" || utils.Configs.SchedulerAlgorithm == "PRIORIDADES" {
-		} else {
-
-		}
-
-		logger.Info(fmt.Sprintf("## (%d:%d) - Bloqueado por: THREAD_JOIN", utils.Execute.PID, utils.Execute.TID))
-		utils.Execute = nil
-
-		respuesta, err := json.Marshal("OK")
-		if err != nil {
-			http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
-		}
-
-		w.WriteHeader(http.StatusOK)
-		w.Write(respuesta)
-
-		planificador.SignalEnviado = true
-		planificador.Semaforo.Signal()
-
+		Responder_JSON(w, estado, respuesta)
 	}
 }
 
 func MUTEX_CREATE(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: MUTEX_CREATE", utils.Execute.PID, utils.Execute.TID))
+		exec, ok := Recibir_syscall(w, r, "MUTEX_CREATE", logger)
+		if !ok {
+			return
+		}
 
 		// Tomamos el valor del tid de la variable del body
 		var mutexName cicloDeInstruccion.EstructuraRecurso
@@ -283,26 +455,24 @@ func MUTEX_CREATE(logger *slog.Logger) http.HandlerFunc {
 			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
 		}
 
-		// Creamos el mutex
-		_, existe := utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso]
-		if existe {
-			respuesta, err := json.Marshal("MUTEX_YA_EXISTE")
-			if err != nil {
-				http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
+		respuesta := "OK"
+		if !Aplicar_syscall(w, planificador.EventosSyscall, exec, func() {
+			pcb, existe := utils.Estado.MapaPCB[exec.PID]
+			if !existe {
+				return
+			}
+			if _, existe := pcb.Mutexs[mutexName.Recurso]; existe {
+				respuesta = "MUTEX_YA_EXISTE"
+				return
 			}
-			w.WriteHeader(http.StatusOK)
-			w.Write(respuesta)
-		}
 
-		// Creamos el mutex y lo agregamos al mapa de mutexs del PCB
-		utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso] = "LIBRE"
-		respuesta, err := json.Marshal("OK")
-		if err != nil {
-			http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
+			// Creamos el mutex y lo agregamos al mapa de mutexs del PCB
+			pcb.Mutexs[mutexName.Recurso] = "LIBRE"
+		}, logger) {
+			return
 		}
 
-		w.WriteHeader(http.StatusOK)
-		w.Write(respuesta)
+		Responder_JSON(w, http.StatusOK, respuesta)
 	}
 }
 
@@ -313,7 +483,10 @@ func MUTEX_CREATE(logger *slog.Logger) http.HandlerFunc {
 func MUTEX_LOCK(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: MUTEX_LOCK", utils.Execute.PID, utils.Execute.TID))
+		exec, ok := Recibir_syscall(w, r, "MUTEX_LOCK", logger)
+		if !ok {
+			return
+		}
 
 		var mutexName cicloDeInstruccion.EstructuraRecurso
 		err := json.NewDecoder(r.Body).Decode(&mutexName)
@@ -321,60 +494,37 @@ func MUTEX_LOCK(logger *slog.Logger) http.HandlerFunc {
 			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
 		}
 
-		// Verificamos que el mutex exista - si NO existe mandamos el hilo a Exit
-		_, existe := utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso]
-		if !existe {
-			planificador.Finalizar_hilo(utils.Execute.TID, utils.Execute.PID, logger)
-			respuesta, err := json.Marshal("HILO_FINALIZADO")
-			if err != nil {
-				http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
-			}
-			w.WriteHeader(http.StatusOK)
-			w.Write(respuesta)
+		var respuesta string
+		estado := http.StatusOK
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			defer planificador.Actualizar_prioridades(exec.PID, logger)
 
-			utils.Execute = nil
-			planificador.SignalEnviado = true
-			planificador.Semaforo.Signal()
-			return
-		}
-
-		// Tomamos el mutex si esta libre
-		if utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso] == "LIBRE" {
-			utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso] = strconv.Itoa(int(utils.Execute.TID))
-			respuesta, err := json.Marshal("MUTEX_TOMADO")
-			if err != nil {
-				w.Write([]byte("Error al codificar mensaje como JSON"))
-				http.Error(w, err.Error(), http.StatusBadRequest)
+			// Verificamos que el mutex exista - si NO existe mandamos el hilo a Exit
+			duenio, existe := utils.Estado.MapaPCB[exec.PID].Mutexs[mutexName.Recurso]
+			if !existe {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta = "HILO_FINALIZADO"
+				return
 			}
-			w.WriteHeader(http.StatusAccepted)
-			w.Write(respuesta)
-
-			return
-		}
-
-		// Si no esta libre, bloqueamos el hilo
-		if utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso] != "LIBRE" {
-			bloqueado := utils.Bloqueado{PID: utils.Execute.PID, TID: utils.Execute.TID, Motivo: utils.Mutex, QuienFue: mutexName.Recurso}
-
-			// Encolamos en ColaBlock y desencolamos de ColaReady
-			utils.Encolar(&planificador.ColaBlocked, bloqueado)
 
-			logger.Info(fmt.Sprintf("## (%d:%d) - Bloqueado por: MUTEX", utils.Execute.PID, utils.Execute.TID))
-
-			// Respondemos con un HILO_BLOQUEADO
-			respuesta, err := json.Marshal("HILO_BLOQUEADO")
-			if err != nil {
-				http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
+			// Tomamos el mutex si esta libre
+			if duenio == "LIBRE" {
+				utils.Estado.MapaPCB[exec.PID].Mutexs[mutexName.Recurso] = strconv.Itoa(int(exec.TID))
+				respuesta, estado = "MUTEX_TOMADO", http.StatusAccepted
+				return
 			}
-			w.WriteHeader(http.StatusOK)
-			w.Write(respuesta)
 
-			utils.Execute = nil
-			planificador.SignalEnviado = true
-			planificador.Semaforo.Signal()
+			// Si no esta libre, bloqueamos el hilo
+			if _, bloqueado := planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.Mutex, QuienFue: mutexName.Recurso}, logger); !bloqueado {
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+			respuesta = "HILO_BLOQUEADO"
+		}, logger) {
 			return
 		}
 
+		Responder_JSON(w, estado, respuesta)
 	}
 }
 
@@ -385,7 +535,10 @@ func MUTEX_LOCK(logger *slog.Logger) http.HandlerFunc {
 func MUTEX_UNLOCK(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: MUTEX_UNLOCK", utils.Execute.PID, utils.Execute.TID))
+		exec, ok := Recibir_syscall(w, r, "MUTEX_UNLOCK", logger)
+		if !ok {
+			return
+		}
 
 		// Tomamos el valor del tid de la variable del body
 		var mutexName cicloDeInstruccion.EstructuraRecurso
@@ -394,87 +547,167 @@ func MUTEX_UNLOCK(logger *slog.Logger) http.HandlerFunc {
 			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
 		}
 
-		// Verificamos que el mutex exista caso contrario mandamos el hilo a exit
-		_, existe := utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso]
-		if !existe {
-			planificador.Finalizar_hilo(utils.Execute.TID, utils.Execute.PID, logger)
-			respuesta, err := json.Marshal("HILO_FINALIZADO")
-			if err != nil {
-				http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
+		var respuesta string
+		estado := http.StatusAccepted
+		if !Aplicar_syscall(w, planificador.EventosReady, exec, func() {
+			// Verificamos que el mutex exista caso contrario mandamos el hilo a exit
+			duenio, existe := utils.Estado.MapaPCB[exec.PID].Mutexs[mutexName.Recurso]
+			if !existe {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta, estado = "HILO_FINALIZADO", http.StatusOK
+				return
+			}
+
+			// Si el mutex existe y no esta tomado por el hilo q invoca la syscall
+			if duenio != strconv.Itoa(int(exec.TID)) {
+				logger.Info("EL hilo no posee el mutex")
+				respuesta = "HILO_NO_POSEE_MUTEX"
+				return
 			}
-			w.WriteHeader(http.StatusOK)
-			w.Write(respuesta)
 
-			utils.Execute = nil
-			planificador.SignalEnviado = true
-			planificador.Semaforo.Signal()
+			// Si alguien quiere el mutex se lo asignamos y lo desbloqueamos, sino queda libre
+			if planificador.Liberar_mutex(exec.PID, mutexName.Recurso, logger) {
+				respuesta = "MUTEX_ASIGNADO"
+			} else {
+				respuesta = "MUTEX_LIBRE"
+			}
+		}, logger) {
 			return
 		}
 
-		// Si el mutex existe, lo asignamos o liberamos segun corresponda
-		if utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso] == strconv.Itoa(int(utils.Execute.TID)) {
-			planificador.Mu.Lock()
-			defer planificador.Mu.Unlock()
-
-			nadieNecesitaMutex := true
+		Responder_JSON(w, estado, respuesta)
+	}
+}
 
-			for _, bloqueado := range planificador.ColaBlocked {
-				// Si alguien quiere el mutex
-				if bloqueado.PID == utils.Execute.PID && bloqueado.Motivo == utils.Mutex && bloqueado.QuienFue == mutexName.Recurso {
+// Asigna los tickets que usan LOTERIA y STRIDE al hilo que hace la syscall (el hilo sigue ejecutando)
+func SET_TICKETS(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 
-					nadieNecesitaMutex = false
-					utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso] = strconv.Itoa(int(bloqueado.TID))
+		exec, ok := Recibir_syscall(w, r, "SET_TICKETS", logger)
+		if !ok {
+			return
+		}
 
-					// Desencolamos de la cola de bloqueados y encolamos en la cola de ready
+		var tickets cicloDeInstruccion.EstructuraTickets
+		err := json.NewDecoder(r.Body).Decode(&tickets)
+		if err != nil || tickets.Tickets <= 0 {
+			logger.Error("Cantidad de tickets invalida")
+			http.Error(w, "Cantidad de tickets invalida", http.StatusBadRequest)
+			return
+		}
 
-					utils.Desencolar_cola_block(bloqueado, &planificador.ColaBlocked)
-					utils.Encolar_ColaReady(planificador.ColaReady, utils.MapaPCB[bloqueado.PID].TCBs[bloqueado.TID])
+		if !Aplicar_syscall(w, planificador.EventosSyscall, exec, func() {
+			tcb, existe := utils.Estado.MapaPCB[exec.PID].TCBs[exec.TID]
+			if existe {
+				tcb.Tickets = tickets.Tickets
+				utils.Estado.Actualizar_TCB(tcb)
+				logger.Info(fmt.Sprintf("## (%d:%d) - Tickets asignados: %d", exec.PID, exec.TID, tickets.Tickets))
+			}
+		}, logger) {
+			return
+		}
 
-					logger.Info(fmt.Sprintf("## (%d:%d) - Desbloqueado por: MUTEX y asignado a el", bloqueado.PID, bloqueado.TID))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("OK"))
+	}
+}
 
-					respuesta, err := json.Marshal("MUTEX_ASIGNADO")
-					if err != nil {
-						http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
-					}
-					w.WriteHeader(http.StatusAccepted)
-					w.Write(respuesta)
+// Declara periódico a un hilo del proceso que hace la syscall, si pasa el test de admisión (el hilo llamador sigue ejecutando)
+func THREAD_SET_RT(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
 
-					return
-				}
-				// Si el mutex no lo necesita nadie
-			}
+		exec, ok := Recibir_syscall(w, r, "THREAD_SET_RT", logger)
+		if !ok {
+			return
+		}
 
-			if nadieNecesitaMutex {
-				utils.MapaPCB[utils.Execute.PID].Mutexs[mutexName.Recurso] = "LIBRE"
-				respuesta, err := json.Marshal("MUTEX_LIBRE")
-				if err != nil {
-					http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
-				}
+		var params cicloDeInstruccion.EstructuraTiempoReal
+		err := json.NewDecoder(r.Body).Decode(&params)
+		if params.Plazo == 0 {
+			params.Plazo = params.Periodo
+		}
+		if err != nil || params.Periodo <= 0 || params.WCET <= 0 || params.WCET > params.Plazo || params.Plazo > params.Periodo {
+			logger.Error("Parametros de tiempo real invalidos")
+			http.Error(w, "Parametros de tiempo real invalidos", http.StatusBadRequest)
+			return
+		}
 
-				logger.Info(fmt.Sprintf("## %s quedo LIBRE", mutexName.Recurso))
+		existe := false
+		respuesta := "OK"
+		if !Aplicar_syscall(w, planificador.EventosReady, exec, func() {
+			var tcb types.TCB
+			tcb, existe = utils.Estado.MapaPCB[exec.PID].TCBs[params.TID]
+			if !existe {
+				return
+			}
 
-				w.WriteHeader(http.StatusAccepted)
-				w.Write(respuesta)
+			tcb.Periodo, tcb.Plazo, tcb.WCET = params.Periodo, params.Plazo, params.WCET
+			if err := planificador.Admitir_tiempo_real(tcb); err != nil {
+				logger.Info(fmt.Sprintf("## (%d:%d) - THREAD_SET_RT rechazado: %s", exec.PID, params.TID, err.Error()))
+				respuesta = "RECHAZADO"
 				return
 			}
+
+			// Si el hilo estaba en READY lo pasamos a la cola de tiempo real
+			planificador.Liberar_trabajo(&tcb, time.Now())
+			utils.Estado.Actualizar_TCB(tcb)
+			if planificador.Algoritmo.Quitar(tcb.PID, tcb.TID) {
+				planificador.Encolar_Ready(tcb)
+			}
+			logger.Info(fmt.Sprintf("## (%d:%d) - Hilo de tiempo real: periodo %d ms, plazo %d ms, WCET %d ms", exec.PID, params.TID, params.Periodo, params.Plazo, params.WCET))
+		}, logger) {
+			return
 		}
-		// Si el mutex existe y no esta tomado por el hilo q invoca la syscall
-		respuesta, err := json.Marshal("HILO_NO_POSEE_MUTEX")
-		if err != nil {
-			http.Error(w, "Error al codificar mensaje como JSON", http.StatusInternalServerError)
+
+		if !existe {
+			logger.Error(fmt.Sprintf("## (%d:%d) - No existe el hilo para THREAD_SET_RT", exec.PID, params.TID))
+			http.Error(w, "Hilo no encontrado", http.StatusBadRequest)
+			return
 		}
 
-		logger.Info("EL hilo no posee el mutex")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(respuesta))
+	}
+}
 
-		w.WriteHeader(http.StatusAccepted)
-		w.Write(respuesta)
+// El hilo periódico que hace la syscall terminó su trabajo; si el siguiente todavia no se liberó queda bloqueado hasta entonces.
+// En un hilo que no es de tiempo real no hace nada y sigue ejecutando
+func THREAD_JOB_END(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "THREAD_JOB_END", logger)
+		if !ok {
+			return
+		}
+
+		var respuesta string
+		estado := http.StatusOK
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			tcb, existe := utils.Estado.MapaPCB[exec.PID].TCBs[exec.TID]
+			if !existe || !planificador.Es_tiempo_real(tcb) {
+				respuesta, estado = "NO_ES_TIEMPO_REAL", http.StatusAccepted
+				return
+			}
+			if planificador.Terminar_trabajo(exec, logger) {
+				respuesta, estado = "TRABAJO_LIBERADO", http.StatusAccepted
+				return
+			}
+			respuesta = "HILO_BLOQUEADO"
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
 	}
 }
 
 func IO(logger *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
-		logger.Info(fmt.Sprintf("## (%d:%d) - Solicitó syscall: IO", utils.Execute.PID, utils.Execute.TID))
+		exec, ok := Recibir_syscall(w, r, "IO", logger)
+		if !ok {
+			return
+		}
 
 		var ms cicloDeInstruccion.EstructuraTiempo
 		err := json.NewDecoder(r.Body).Decode(&ms)
@@ -482,19 +715,39 @@ func IO(logger *slog.Logger) http.HandlerFunc {
 			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
 		}
 
-		solicitud := utils.SolicitudIO{
-			PID:       utils.Execute.PID,
-			TID:       utils.Execute.TID,
-			Duracion:  ms.MS,
-			Timestamp: time.Now(),
+		// Si el dispositivo no existe el hilo se finaliza, igual que con un mutex inexistente
+		dispositivo, existe := planificador.Buscar_dispositivo(ms.Dispositivo)
+		if !existe {
+			logger.Error(fmt.Sprintf("## (%d:%d) - No existe el dispositivo de IO %s", exec.PID, exec.TID, ms.Dispositivo))
+			if !Aplicar_syscall(w, planificador.EventosExit, exec, func() {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+			}, logger) {
+				return
+			}
+			Responder_JSON(w, http.StatusOK, "HILO_FINALIZADO")
+			return
 		}
-		utils.Encolar(&planificador.ColaBlocked, utils.Bloqueado{PID: utils.Execute.PID, TID: utils.Execute.TID, Motivo: utils.IO})
-		utils.Encolar(&planificador.ColaIO, solicitud)
-		logger.Info(fmt.Sprintf("## (%d:%d) - Bloqueado por: IO", utils.Execute.PID, utils.Execute.TID))
 
-		utils.Execute = nil
-		planificador.SignalEnviado = true
-		planificador.Semaforo.Signal()
+		solicitud := utils.SolicitudIO{
+			Dispositivo: dispositivo.Nombre,
+			PID:         exec.PID,
+			TID:         exec.TID,
+			Duracion:    ms.MS,
+			Timestamp:   time.Now(),
+		}
+		bloqueado := false
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			solicitud.ID, bloqueado = planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.IO, QuienFue: dispositivo.Nombre}, logger)
+			if bloqueado {
+				planificador.Solicitar_IO(solicitud, logger)
+			}
+		}, logger) {
+			return
+		}
+		if !bloqueado {
+			Responder_JSON(w, http.StatusOK, "HILO_FINALIZADO")
+			return
+		}
 
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
@@ -513,43 +766,42 @@ func Recibir_desalojo(logger *slog.Logger) http.HandlerFunc {
 			return
 		}
 
-		switch magic.Motivo {
-		case "FIN_QUANTUM":
+		planificador.Notificar(planificador.EventosInterrupcion, func() {
+			// Buscamos en que CPU estaba ejecutando el hilo desalojado
+			exec := utils.Buscar_Execute(magic.PID, magic.TID)
 
-			planificador.Mu.Lock()
-
-			if utils.Execute == nil {
-				break
-			}
-
-			tcb, existe := utils.MapaPCB[magic.PID].TCBs[magic.TID]
-			if existe {
-				if utils.Execute.PID == magic.PID && utils.Execute.TID == magic.TID {
-					utils.Encolar_ColaReady(planificador.ColaReady, tcb)
+			switch magic.Motivo {
+			case "FIN_QUANTUM":
+				if exec == nil {
+					break
+				}
+				planificador.Terminar_rafaga(exec, true)
+				tcb, existe := utils.Estado.MapaPCB[magic.PID].TCBs[magic.TID]
+				if existe {
+					planificador.Algoritmo.FinDeQuantum(tcb)
 					logger.Info(fmt.Sprintf("## (%d:%d) - Desalojado por fin de Quantum", magic.PID, magic.TID))
 				}
-			}
-
-			utils.Execute = nil
 
-			planificador.Mu.Unlock()
+			case "SEGMENTATION_FAULT":
+				planificador.Terminar_rafaga(exec, false)
+				planificador.Finalizar_proceso(magic.PID, utils.FIN_SEGMENTATION_FAULT, logger)
 
-			planificador.SignalEnviado = true
-			planificador.Semaforo.Signal()
+			case "FINALIZACION":
+				// La CPU ya dejó de ejecutar al hilo que se finalizó (Desalojar_si_ejecuta): recién ahora queda libre
+				utils.Estado.Liberar_CPU(exec)
 
-		case "SEGMENTATION_FAULT":
-			planificador.Finalizar_proceso(magic.PID, logger)
-			utils.Execute = nil
-			planificador.SignalEnviado = true
-			planificador.Semaforo.Signal()
-
-		case "PRIORIDAD":
-			utils.Execute = nil
-			logger.Info(fmt.Sprintf("## (%d:%d) - Desalojado por PRIORIDAD", magic.PID, magic.TID))
-			utils.Encolar_ColaReady(planificador.ColaReady, utils.Obtener_PCB_por_PID(magic.PID).TCBs[magic.TID])
-			planificador.SignalEnviado = true
-			planificador.Semaforo.Signal()
-		}
+			case "PRIORIDAD", "SENIAL":
+				// Con SENIAL vuelve a READY y el núcleo le entrega las señales pendientes despues del evento
+				if exec == nil {
+					break
+				}
+				planificador.Terminar_rafaga(exec, true)
+				logger.Info(fmt.Sprintf("## (%d:%d) - Desalojado por %s", magic.PID, magic.TID, magic.Motivo))
+				if tcb, existe := utils.Estado.MapaPCB[magic.PID].TCBs[magic.TID]; existe {
+					planificador.Encolar_Ready(tcb)
+				}
+			}
+		})
 
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
@@ -0,0 +1,465 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/sisoputnfrba/tp-golang/cpu/cicloDeInstruccion"
+	"github.com/sisoputnfrba/tp-golang/kernel/planificador"
+	"github.com/sisoputnfrba/tp-golang/kernel/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Syscalls de semáforos, variables de condición, rwlocks y barreras. Igual que los mutex, pertenecen al proceso que los crea
+// y los hilos que los esperan quedan en la cola de bloqueados con el nombre del recurso en QuienFue
+
+// Crea un semáforo contador con su valor inicial
+func SEM_CREATE(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "SEM_CREATE", logger)
+		if !ok {
+			return
+		}
+
+		var semaforo cicloDeInstruccion.EstructuraSemaforo
+		err := json.NewDecoder(r.Body).Decode(&semaforo)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		respuesta := "OK"
+		if !Aplicar_syscall(w, planificador.EventosSyscall, exec, func() {
+			pcb, existe := utils.Estado.MapaPCB[exec.PID]
+			if !existe {
+				return
+			}
+			if _, existe := pcb.Semaforos[semaforo.Recurso]; existe {
+				respuesta = "SEM_YA_EXISTE"
+				return
+			}
+			pcb.Semaforos[semaforo.Recurso] = max(semaforo.Valor, 0)
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, http.StatusOK, respuesta)
+	}
+}
+
+// 3 CASOS:
+// 1. Si el semáforo no existe, finaliza el hilo y responde con "HILO_FINALIZADO"
+// 2. Si el contador es mayor a 0, lo decrementa y responde con "SEM_TOMADO"
+// 3. Si el contador es 0, bloquea el hilo y responde con "HILO_BLOQUEADO"
+func SEM_WAIT(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "SEM_WAIT", logger)
+		if !ok {
+			return
+		}
+
+		var semaforo cicloDeInstruccion.EstructuraRecurso
+		err := json.NewDecoder(r.Body).Decode(&semaforo)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		var respuesta string
+		estado := http.StatusOK
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			valor, existe := utils.Estado.MapaPCB[exec.PID].Semaforos[semaforo.Recurso]
+			if !existe {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+
+			if valor > 0 {
+				utils.Estado.MapaPCB[exec.PID].Semaforos[semaforo.Recurso]--
+				respuesta, estado = "SEM_TOMADO", http.StatusAccepted
+				return
+			}
+
+			if _, bloqueado := planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.Semaforo, QuienFue: semaforo.Recurso}, logger); !bloqueado {
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+			respuesta = "HILO_BLOQUEADO"
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
+
+// Si hay hilos esperando el semáforo despierta al primero, sino incrementa el contador. El hilo sigue ejecutando
+// Si el semáforo no existe responde con "HILO_FINALIZADO" y finaliza el hilo
+func SEM_POST(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "SEM_POST", logger)
+		if !ok {
+			return
+		}
+
+		var semaforo cicloDeInstruccion.EstructuraRecurso
+		err := json.NewDecoder(r.Body).Decode(&semaforo)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		respuesta := "OK"
+		estado := http.StatusAccepted
+		if !Aplicar_syscall(w, planificador.EventosReady, exec, func() {
+			if _, existe := utils.Estado.MapaPCB[exec.PID].Semaforos[semaforo.Recurso]; !existe {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta, estado = "HILO_FINALIZADO", http.StatusOK
+				return
+			}
+			planificador.Liberar_semaforo(exec.PID, semaforo.Recurso, logger)
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
+
+func COND_CREATE(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "COND_CREATE", logger)
+		if !ok {
+			return
+		}
+
+		var condicion cicloDeInstruccion.EstructuraRecurso
+		err := json.NewDecoder(r.Body).Decode(&condicion)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		respuesta := "OK"
+		if !Aplicar_syscall(w, planificador.EventosSyscall, exec, func() {
+			pcb, existe := utils.Estado.MapaPCB[exec.PID]
+			if !existe {
+				return
+			}
+			if pcb.Condiciones[condicion.Recurso] {
+				respuesta = "COND_YA_EXISTE"
+				return
+			}
+			pcb.Condiciones[condicion.Recurso] = true
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, http.StatusOK, respuesta)
+	}
+}
+
+// Libera el mutex y bloquea al hilo hasta que otro haga COND_SIGNAL o COND_BROADCAST; al despertarse
+// vuelve a tomar el mutex antes de pasar a READY.
+// Si la condición o el mutex no existen responde con "HILO_FINALIZADO" y finaliza el hilo
+// Si el hilo no posee el mutex responde "HILO_NO_POSEE_MUTEX" y sigue ejecutando
+func COND_WAIT(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "COND_WAIT", logger)
+		if !ok {
+			return
+		}
+
+		var condicion cicloDeInstruccion.EstructuraCondicion
+		err := json.NewDecoder(r.Body).Decode(&condicion)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		var respuesta string
+		estado := http.StatusOK
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			pcb := utils.Estado.MapaPCB[exec.PID]
+			duenio, existe := pcb.Mutexs[condicion.Mutex]
+			if !existe || !pcb.Condiciones[condicion.Recurso] {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+
+			if duenio != strconv.Itoa(int(exec.TID)) {
+				logger.Info("EL hilo no posee el mutex")
+				respuesta, estado = "HILO_NO_POSEE_MUTEX", http.StatusAccepted
+				return
+			}
+
+			if _, bloqueado := planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: utils.Condicion, QuienFue: condicion.Recurso, Mutex: condicion.Mutex}, logger); !bloqueado {
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+			planificador.Liberar_mutex(exec.PID, condicion.Mutex, logger)
+			respuesta = "HILO_BLOQUEADO"
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
+
+// Despierta al primer hilo que espera la condición; si no hay ninguno no pasa nada. El hilo sigue ejecutando
+func COND_SIGNAL(logger *slog.Logger) http.HandlerFunc {
+	return despertar_condicion("COND_SIGNAL", false, logger)
+}
+
+// Despierta a todos los hilos que esperan la condición. El hilo sigue ejecutando
+func COND_BROADCAST(logger *slog.Logger) http.HandlerFunc {
+	return despertar_condicion("COND_BROADCAST", true, logger)
+}
+
+func despertar_condicion(syscall string, todos bool, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, syscall, logger)
+		if !ok {
+			return
+		}
+
+		var condicion cicloDeInstruccion.EstructuraRecurso
+		err := json.NewDecoder(r.Body).Decode(&condicion)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		respuesta := "OK"
+		estado := http.StatusAccepted
+		if !Aplicar_syscall(w, planificador.EventosReady, exec, func() {
+			if !utils.Estado.MapaPCB[exec.PID].Condiciones[condicion.Recurso] {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta, estado = "HILO_FINALIZADO", http.StatusOK
+				return
+			}
+			despertados := planificador.Despertar_de_condicion(exec.PID, condicion.Recurso, todos, logger)
+			logger.Info(fmt.Sprintf("## %s %s despertó %d hilos", syscall, condicion.Recurso, despertados))
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
+
+func RWLOCK_CREATE(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "RWLOCK_CREATE", logger)
+		if !ok {
+			return
+		}
+
+		var rwlock cicloDeInstruccion.EstructuraRecurso
+		err := json.NewDecoder(r.Body).Decode(&rwlock)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		respuesta := "OK"
+		if !Aplicar_syscall(w, planificador.EventosSyscall, exec, func() {
+			pcb, existe := utils.Estado.MapaPCB[exec.PID]
+			if !existe {
+				return
+			}
+			if _, existe := pcb.RWLocks[rwlock.Recurso]; existe {
+				respuesta = "RWLOCK_YA_EXISTE"
+				return
+			}
+			pcb.RWLocks[rwlock.Recurso] = types.RWLock{Escritor: "LIBRE"}
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, http.StatusOK, respuesta)
+	}
+}
+
+// Toma el rwlock en lectura; varios lectores lo pueden tener a la vez mientras no haya escritor
+func RWLOCK_RDLOCK(logger *slog.Logger) http.HandlerFunc {
+	return tomar_rwlock("RWLOCK_RDLOCK", utils.Lectura, logger)
+}
+
+// Toma el rwlock en escritura; el escritor lo tiene solo
+func RWLOCK_WRLOCK(logger *slog.Logger) http.HandlerFunc {
+	return tomar_rwlock("RWLOCK_WRLOCK", utils.Escritura, logger)
+}
+
+// 3 CASOS:
+// 1. Si el rwlock no existe, finaliza el hilo y responde con "HILO_FINALIZADO"
+// 2. Si se lo puede tomar en ese modo, lo toma y responde con "RWLOCK_TOMADO"
+// 3. Si no, bloquea el hilo y responde con "HILO_BLOQUEADO"
+func tomar_rwlock(syscall string, modo utils.Motivo, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, syscall, logger)
+		if !ok {
+			return
+		}
+
+		var rwlock cicloDeInstruccion.EstructuraRecurso
+		err := json.NewDecoder(r.Body).Decode(&rwlock)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		var respuesta string
+		estado := http.StatusOK
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			pcb := utils.Estado.MapaPCB[exec.PID]
+			lock, existe := pcb.RWLocks[rwlock.Recurso]
+			if !existe {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+
+			if modo == utils.Lectura && planificador.Puede_leer(exec.PID, rwlock.Recurso) {
+				lock.Lectores = append(lock.Lectores, exec.TID)
+				pcb.RWLocks[rwlock.Recurso] = lock
+				respuesta, estado = "RWLOCK_TOMADO", http.StatusAccepted
+				return
+			}
+			if modo == utils.Escritura && planificador.Puede_escribir(exec.PID, rwlock.Recurso) {
+				lock.Escritor = strconv.Itoa(int(exec.TID))
+				pcb.RWLocks[rwlock.Recurso] = lock
+				respuesta, estado = "RWLOCK_TOMADO", http.StatusAccepted
+				return
+			}
+
+			if _, bloqueado := planificador.Bloquear_hilo(exec, utils.Bloqueado{PID: exec.PID, TID: exec.TID, Motivo: modo, QuienFue: rwlock.Recurso}, logger); !bloqueado {
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+			respuesta = "HILO_BLOQUEADO"
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
+
+// Suelta el rwlock (en el modo en que lo tenga el hilo) y se lo da a los que esperan según rwlock_preference.
+// Si el rwlock no existe responde con "HILO_FINALIZADO" y finaliza el hilo
+// Si el hilo no lo tiene tomado responde "HILO_NO_POSEE_RWLOCK"
+func RWLOCK_UNLOCK(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "RWLOCK_UNLOCK", logger)
+		if !ok {
+			return
+		}
+
+		var rwlock cicloDeInstruccion.EstructuraRecurso
+		err := json.NewDecoder(r.Body).Decode(&rwlock)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		respuesta := "RWLOCK_LIBERADO"
+		estado := http.StatusAccepted
+		if !Aplicar_syscall(w, planificador.EventosReady, exec, func() {
+			if _, existe := utils.Estado.MapaPCB[exec.PID].RWLocks[rwlock.Recurso]; !existe {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta, estado = "HILO_FINALIZADO", http.StatusOK
+				return
+			}
+			if !planificador.Soltar_rwlock(exec.PID, exec.TID, rwlock.Recurso) {
+				logger.Info("EL hilo no posee el rwlock")
+				respuesta = "HILO_NO_POSEE_RWLOCK"
+				return
+			}
+			planificador.Despertar_rwlock(exec.PID, rwlock.Recurso, logger)
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
+
+// Crea una barrera que se libera cuando llegan la cantidad de hilos indicada
+func BARRIER_CREATE(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "BARRIER_CREATE", logger)
+		if !ok {
+			return
+		}
+
+		var barrera cicloDeInstruccion.EstructuraBarrera
+		err := json.NewDecoder(r.Body).Decode(&barrera)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		respuesta := "OK"
+		if !Aplicar_syscall(w, planificador.EventosSyscall, exec, func() {
+			pcb, existe := utils.Estado.MapaPCB[exec.PID]
+			if !existe {
+				return
+			}
+			if _, existe := pcb.Barreras[barrera.Recurso]; existe {
+				respuesta = "BARRERA_YA_EXISTE"
+				return
+			}
+			pcb.Barreras[barrera.Recurso] = max(barrera.Cantidad, 1)
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, http.StatusOK, respuesta)
+	}
+}
+
+// 3 CASOS:
+// 1. Si la barrera no existe, finaliza el hilo y responde con "HILO_FINALIZADO"
+// 2. Si es el último hilo que faltaba, libera a los que esperan y responde con "BARRERA_LIBERADA"
+// 3. Si faltan hilos, bloquea el hilo y responde con "HILO_BLOQUEADO"
+func BARRIER_WAIT(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+
+		exec, ok := Recibir_syscall(w, r, "BARRIER_WAIT", logger)
+		if !ok {
+			return
+		}
+
+		var barrera cicloDeInstruccion.EstructuraRecurso
+		err := json.NewDecoder(r.Body).Decode(&barrera)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+		}
+
+		var respuesta string
+		estado := http.StatusOK
+		if !Aplicar_syscall(w, planificador.EventosBloqueo, exec, func() {
+			if _, existe := utils.Estado.MapaPCB[exec.PID].Barreras[barrera.Recurso]; !existe {
+				planificador.Finalizar_hilo_en_ejecucion(exec, logger)
+				respuesta = "HILO_FINALIZADO"
+				return
+			}
+			if planificador.Llegar_a_barrera(exec, barrera.Recurso, logger) {
+				respuesta, estado = "BARRERA_LIBERADA", http.StatusAccepted
+				return
+			}
+			respuesta = "HILO_BLOQUEADO"
+		}, logger) {
+			return
+		}
+
+		Responder_JSON(w, estado, respuesta)
+	}
+}
@@ -12,13 +12,31 @@ func Encolar[T any](cola *[]T, elemento T) {
 	*cola = append(*cola, elemento)
 }
 
-// Esta se usa para encolar en la cola de ready, ya que se necesita saber la prioridad del proceso
-func Encolar_ColaReady(colaReady map[int][]types.TCB, tcb types.TCB) {
-	if Configs.SchedulerAlgorithm == "CMN" {
-		colaReady[tcb.Prioridad] = append(colaReady[tcb.Prioridad], tcb)
-	} else {
-		colaReady[0] = append(colaReady[0], tcb)
+// Encola el TCB al final del nivel indicado de la cola de ready (el nivel lo decide el algoritmo de planificación)
+func Encolar_ColaReady(colaReady map[int][]types.TCB, nivel int, tcb types.TCB) {
+	colaReady[nivel] = append(colaReady[nivel], tcb)
+}
+
+// Saca el TCB indicado de la cola de ready, sin importar en que nivel esté; Retorna false si no estaba encolado
+func Quitar_TCB_de_ColaReady(colasReady map[int][]types.TCB, pid uint32, tid uint32) bool {
+	for nivel, cola := range colasReady {
+		for i, tcb := range cola {
+			if tcb.PID == pid && tcb.TID == tid {
+				colasReady[nivel] = append(cola[:i:i], cola[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Devuelve una copia de la cola de ready, para que quien la consulte no modifique las colas reales
+func Copiar_ColaReady(colasReady map[int][]types.TCB) map[int][]types.TCB {
+	copia := make(map[int][]types.TCB, len(colasReady))
+	for nivel, cola := range colasReady {
+		copia[nivel] = append([]types.TCB(nil), cola...)
 	}
+	return copia
 }
 
 // Sirve para desencolar un TCB de la cola de ready indicando su prioridad (para FIFO y PRIORIDADES usamos 0); Retorna el elemento y un booleano que indica si fue exitoso
@@ -49,27 +67,58 @@ func Desencolar[T any](cola *[]T) T {
 	return elemento
 }
 
-// Desencola un elemento de la cola de BLOQUEADOS por motivo y retorna ese elemento (IMPORTANTE: tener en cuenta que Motivo es un tipo de dato Const)
-func Desencolar_Por_Motivo(cola *[]Bloqueado, motivo Motivo) *Bloqueado {
+// Saca de la cola de BLOQUEADOS el bloqueo con ese ID y lo retorna; el bool es false si no estaba
+func Desencolar_cola_block(id int, cola *[]Bloqueado) (Bloqueado, bool) {
 	for i, elem := range *cola {
-		if elem.Motivo == motivo {
-			elemento := elem
+		if elem.ID == id {
 			*cola = append((*cola)[:i], (*cola)[i+1:]...)
-			return &elemento
+			return elem, true
 		}
 	}
-	return nil // No se encontró un elemento con el motivo especificado
+	return Bloqueado{}, false
 }
 
-func Desencolar_cola_block(bloqueado Bloqueado, cola *[]Bloqueado) bool {
-	for i, elem := range *cola {
-		if elem.PID == bloqueado.PID && elem.TID == bloqueado.TID {
-			*cola = append((*cola)[:i], (*cola)[i+1:]...)
-			return true
+// Busca el primer hilo del proceso que está bloqueado por ese motivo y recurso (orden de llegada)
+func Primer_bloqueado(cola []Bloqueado, pid uint32, motivo Motivo, quienFue string) (Bloqueado, bool) {
+	for _, elem := range cola {
+		if elem.PID == pid && elem.Motivo == motivo && elem.QuienFue == quienFue {
+			return elem, true
 		}
 	}
-	return false
+	return Bloqueado{}, false
+}
 
+// Todos los hilos del proceso bloqueados por ese motivo y recurso, en orden de llegada.
+// Es la cola de espera de cada mutex, semáforo, rwlock, etc.: no se guarda aparte para que al finalizar un hilo
+// alcance con sacarlo de la cola de bloqueados
+func Bloqueados_por(cola []Bloqueado, pid uint32, motivo Motivo, quienFue string) []Bloqueado {
+	var bloqueados []Bloqueado
+	for _, elem := range cola {
+		if elem.PID == pid && elem.Motivo == motivo && elem.QuienFue == quienFue {
+			bloqueados = append(bloqueados, elem)
+		}
+	}
+	return bloqueados
+}
+
+// Igual que Primer_bloqueado pero de cualquier proceso, para los recursos que comparten los procesos (colas de mensajes)
+func Primer_bloqueado_global(cola []Bloqueado, motivo Motivo, quienFue string) (Bloqueado, bool) {
+	for _, elem := range cola {
+		if elem.Motivo == motivo && elem.QuienFue == quienFue {
+			return elem, true
+		}
+	}
+	return Bloqueado{}, false
+}
+
+// Busca el bloqueo del hilo por el motivo indicado; el bool es false si el hilo no está bloqueado por ese motivo
+func Buscar_bloqueo(cola []Bloqueado, pid uint32, tid uint32, motivo Motivo) (Bloqueado, bool) {
+	for _, elem := range cola {
+		if elem.PID == pid && elem.TID == tid && elem.Motivo == motivo {
+			return elem, true
+		}
+	}
+	return Bloqueado{}, false
 }
 
 func Sacar_TCB_Del_Map(mapaPCBS *map[uint32]types.PCB, pid uint32, tid uint32, logger *slog.Logger) {
@@ -94,12 +143,38 @@ func Sacar_TCB_Del_Map(mapaPCBS *map[uint32]types.PCB, pid uint32, tid uint32, l
 	(*mapaPCBS)[pid] = pcb
 }
 
-// Devuelve dos valores, el primero es la solicitud y el segundo es un booleano que indica si la cola está vacía
-func Proxima_solicitud(cola *[]SolicitudIO) (SolicitudIO, bool) {
+// Agrega la solicitud a la cola de su dispositivo
+func Encolar_solicitud_IO(solicitud SolicitudIO) {
+	Estado.ColasIO[solicitud.Dispositivo] = append(Estado.ColasIO[solicitud.Dispositivo], solicitud)
+}
+
+// Saca de la cola la proxima solicitud a atender según la politica del dispositivo (FIFO, SJF o PRIORIDAD).
+// Devuelve dos valores, el primero es la solicitud y el segundo es un booleano que indica si había alguna
+func Proxima_solicitud(cola *[]SolicitudIO, politica string) (SolicitudIO, bool) {
 	if len(*cola) == 0 {
 		return SolicitudIO{}, false
 	}
-	solicitud := (*cola)[0]
-	*cola = (*cola)[1:] // Remueve la primera solicitud del slice
+
+	// Ante empate queda la que llegó primero
+	elegida := 0
+	for i, solicitud := range *cola {
+		switch politica {
+		case "SJF":
+			if solicitud.Duracion < (*cola)[elegida].Duracion {
+				elegida = i
+			}
+		case "PRIORIDAD":
+			if prioridadDeHilo(solicitud.PID, solicitud.TID) < prioridadDeHilo((*cola)[elegida].PID, (*cola)[elegida].TID) {
+				elegida = i
+			}
+		}
+	}
+
+	solicitud := (*cola)[elegida]
+	*cola = append((*cola)[:elegida], (*cola)[elegida+1:]...) // Remueve la solicitud del slice
 	return solicitud, true
 }
+
+func prioridadDeHilo(pid uint32, tid uint32) int {
+	return Estado.MapaPCB[pid].TCBs[tid].Prioridad
+}
@@ -3,22 +3,66 @@ package utils
 import (
 	"encoding/json"
 	"log"
+	"math/rand"
 	"os"
+	"time"
 )
 
+// Dirección de un módulo CPU
+type CPU struct {
+	Ip   string `json:"ip"`
+	Port int    `json:"port"`
+}
+
+// Dispositivo de IO: cada uno tiene su cola, atiende hasta Concurrencia solicitudes a la vez
+// y elige la proxima según su politica (FIFO, SJF = menor duración, PRIORIDAD = prioridad del hilo)
+type DispositivoIO struct {
+	Nombre       string `json:"name"`
+	Concurrencia int    `json:"concurrency"`
+	Politica     string `json:"policy"`
+}
+
 type Config struct {
-	Port               int    `json:"port"`
-	IpMemory           string `json:"ip_memory"`
-	PortMemory         int    `json:"port_memory"`
-	IpCPU              string `json:"ip_cpu"`
-	PortCPU            int    `json:"port_cpu"`
-	SchedulerAlgorithm string `json:"scheduler_algorithm"`
-	Quantum            int    `json:"quantum"`
-	LogLevel           string `json:"log_level"`
+	Port               int             `json:"port"`
+	IpMemory           string          `json:"ip_memory"`
+	PortMemory         int             `json:"port_memory"`
+	IpCPU              string          `json:"ip_cpu"`
+	PortCPU            int             `json:"port_cpu"`
+	CPUs               []CPU           `json:"cpus"` // Si no se indica, se usa una sola CPU con ip_cpu y port_cpu
+	SchedulerAlgorithm string          `json:"scheduler_algorithm"`
+	BurstAlpha         float64         `json:"burst_alpha"`            // Peso de la ultima ráfaga real en la estimación (SJF y SRT)
+	InitialBurst       int             `json:"initial_burst_estimate"` // Estimación inicial de ráfaga de cada hilo (en milisegundos)
+	MlfqQuantums       []int           `json:"mlfq_quantums"`          // Quantum de cada nivel del MLFQ (la cantidad de niveles es el largo)
+	MlfqBoost          int             `json:"mlfq_boost_interval"`    // Cada cuantos milisegundos se suben todos los hilos al primer nivel (0 = nunca)
+	MlfqPromover       bool            `json:"mlfq_promote_on_block"`  // Si un hilo que se bloquea antes del quantum sube un nivel (sino se queda en el suyo)
+	RandomSeed         int64           `json:"random_seed"`            // Semilla de los algoritmos que sortean (0 = toma la hora actual)
+	CfsLatencia        int             `json:"cfs_target_latency"`     // Periodo (ms) en el que CFS intenta que ejecuten todos los hilos en READY
+	CfsGranularidad    int             `json:"cfs_min_granularity"`    // Porción mínima de CPU (ms) que da CFS a un hilo
+	GrupoShareDefault  int             `json:"group_default_share"`    // Shares de CPU de un proceso en el planificador GRUPOS
+	GrupoShares        map[string]int  `json:"group_shares"`           // Shares de CPU por PID (clave: PID), pisan al default
+	RtAlgorithm        string          `json:"rt_algorithm"`           // Algoritmo de los hilos de tiempo real: EDF (por defecto) o RM
+	DispositivosIO     []DispositivoIO `json:"io_devices"`             // El primero es el que usa la instrucción IO sin dispositivo
+	RwlockPreferencia  string          `json:"rwlock_preference"`      // A quién se le da el rwlock cuando se libera: LECTORES (por defecto) o ESCRITORES
+	ProtocoloMutex     string          `json:"mutex_protocol"`         // Contra la inversión de prioridades: NINGUNO (por defecto), HERENCIA o TECHO
+	PoliticaMutex      string          `json:"mutex_handoff"`          // A quién se le da el mutex al liberarlo: FIFO (por defecto), PRIORIDAD o ALEATORIO
+	DeadlockRecovery   string          `json:"deadlock_recovery"`      // Qué hacer al detectar un deadlock: REPORTAR (por defecto), MATAR_HILO o MATAR_PROCESO
+	Recursos           map[string]int  `json:"resources"`              // Instancias totales de cada recurso que administra el algoritmo del banquero
+	CapacidadMQ        int             `json:"mq_capacity"`            // Mensajes que entran en cada cola de mensajes antes de que MQ_SEND se bloquee
+	Quantum            int             `json:"quantum"`
+	LogLevel           string          `json:"log_level"`
 }
 
 var Configs Config
 
+// Generador de numeros aleatorios del kernel, con la semilla del config para que las corridas se puedan repetir
+var Random *rand.Rand
+
+// Capacidad de las colas de mensajes si mq_capacity no está en el config
+const CAPACIDAD_MQ_DEFAULT = 8
+
+// Peso de la ultima ráfaga si burst_alpha no está en el config (o no está entre 0 y 1)
+const BURST_ALPHA_DEFAULT = 0.5
+
 func Iniciar_Configuracion(filePath string) Config {
 
 	configFile, err := os.Open(filePath)
@@ -30,5 +74,29 @@ func Iniciar_Configuracion(filePath string) Config {
 	jsonParser := json.NewDecoder(configFile)
 	jsonParser.Decode(&Configs)
 
+	if len(Configs.CPUs) == 0 {
+		Configs.CPUs = []CPU{{Ip: Configs.IpCPU, Port: Configs.PortCPU}}
+	}
+
+	// Sin dispositivos configurados hay uno solo que atiende de a una solicitud por orden de llegada
+	if len(Configs.DispositivosIO) == 0 {
+		Configs.DispositivosIO = []DispositivoIO{{Nombre: "GENERICO", Concurrencia: 1, Politica: "FIFO"}}
+	}
+	for i := range Configs.DispositivosIO {
+		Configs.DispositivosIO[i].Concurrencia = max(Configs.DispositivosIO[i].Concurrencia, 1)
+	}
+	if Configs.CapacidadMQ <= 0 {
+		Configs.CapacidadMQ = CAPACIDAD_MQ_DEFAULT
+	}
+	if Configs.BurstAlpha <= 0 || Configs.BurstAlpha > 1 {
+		Configs.BurstAlpha = BURST_ALPHA_DEFAULT
+	}
+
+	semilla := Configs.RandomSeed
+	if semilla == 0 {
+		semilla = time.Now().UnixNano()
+	}
+	Random = rand.New(rand.NewSource(semilla))
+
 	return Configs
 }
@@ -0,0 +1,228 @@
+package utils
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Estado del kernel: mapa de PCBs, colas de cada estado y el hilo que ejecuta en cada CPU.
+// Solo lo modifica la goroutine del núcleo (planificador.Nucleo); los handlers y timers lo tocan a traves de
+// un evento (planificador.Notificar), asi cada operación se aplica entera sin que se intercale otra.
+type KernelState struct {
+	MapaPCB     map[uint32]types.PCB // PCBs con su PID como clave
+	ColaNew     []types.ProcesoNew   // Procesos esperando memoria (manejada por FIFO)
+	ColaReady   map[int][]types.TCB  // Cola de ready por nivel, la ordena el algoritmo de planificación
+	ColaBlocked []Bloqueado
+	UltimoID    int                      // Ultimo ID de bloqueo asignado
+	ColasIO     map[string][]SolicitudIO // Solicitudes esperando cada dispositivo de IO
+	IOEnCurso   map[string]int           // Solicitudes que está atendiendo cada dispositivo
+	ColaExit    []Finalizacion           // Hilos finalizados, con como terminó cada uno
+	Finalizados map[uint32]Finalizacion  // Como terminó cada proceso, con su PID como clave
+	ColasMQ     map[string][]uint32      // Colas de mensajes entre procesos (MQ_OPEN), con los valores en orden de llegada
+	Executes    []*ExecuteActual         // Hilo ejecutando en cada CPU (misma posición que en Configs.CPUs); nil si la CPU está libre
+	Seniales    []Senial                 // Señales que todavia no se entregaron, en orden de llegada
+	Detenidos   map[uint32]bool          // Procesos detenidos con PROCESS_STOP
+	ColaStopped []types.TCB              // Hilos de procesos detenidos que estarían en READY
+	Zombies     map[uint32]Zombie        // Procesos finalizados que su padre todavia no esperó, con su PID como clave
+}
+
+var Estado KernelState
+
+// Inicializa las colas vacías y un lugar de ejecución por cada CPU del config
+func Inicializar_estado() {
+	Estado = KernelState{
+		MapaPCB:     make(map[uint32]types.PCB),
+		ColaNew:     []types.ProcesoNew{},
+		ColaReady:   make(map[int][]types.TCB),
+		ColaBlocked: []Bloqueado{},
+		ColasIO:     make(map[string][]SolicitudIO),
+		IOEnCurso:   make(map[string]int),
+		ColaExit:    []Finalizacion{},
+		Finalizados: make(map[uint32]Finalizacion),
+		ColasMQ:     make(map[string][]uint32),
+		Executes:    make([]*ExecuteActual, len(Configs.CPUs)),
+		Seniales:    []Senial{},
+		Detenidos:   make(map[uint32]bool),
+		ColaStopped: []types.TCB{},
+		Zombies:     make(map[uint32]Zombie),
+	}
+}
+
+// Pone al hilo a ejecutar en su CPU
+func (e *KernelState) Ocupar_CPU(exec *ExecuteActual) {
+	e.Executes[exec.CPU] = exec
+}
+
+// Libera la CPU si todavia está ejecutando esa misma ejecución; Retorna false si ya se había liberado
+func (e *KernelState) Liberar_CPU(exec *ExecuteActual) bool {
+	if exec == nil || e.Executes[exec.CPU] != exec {
+		return false
+	}
+	e.Executes[exec.CPU] = nil
+	return true
+}
+
+// Guarda en el mapa de PCBs los cambios hechos a una copia del TCB (si el hilo sigue existiendo)
+func (e *KernelState) Actualizar_TCB(tcb types.TCB) {
+	pcb, existe := e.MapaPCB[tcb.PID]
+	if !existe {
+		return
+	}
+	if _, existe := pcb.TCBs[tcb.TID]; existe {
+		pcb.TCBs[tcb.TID] = tcb
+	}
+}
+
+// Pasa el hilo a la cola de bloqueados con un ID nuevo y devuelve ese ID; el bool es false si el hilo ya no existe
+// (por ejemplo porque finalizaron su proceso), y en ese caso no se lo encola
+func (e *KernelState) Bloquear_hilo(bloqueado Bloqueado) (int, bool) {
+	if _, existe := e.MapaPCB[bloqueado.PID].TCBs[bloqueado.TID]; !existe {
+		return 0, false
+	}
+	e.UltimoID++
+	bloqueado.ID = e.UltimoID
+	Encolar(&e.ColaBlocked, bloqueado)
+	return bloqueado.ID, true
+}
+
+// Saca de la cola de bloqueados el bloqueo con ese ID y devuelve el TCB del hilo;
+// el bool es false si ya no estaba bloqueado (por ejemplo porque finalizaron su proceso) o el hilo ya no existe
+func (e *KernelState) Desbloquear_hilo(id int) (types.TCB, bool) {
+	bloqueado, existe := Desencolar_cola_block(id, &e.ColaBlocked)
+	if !existe {
+		return types.TCB{}, false
+	}
+	tcb, existe := e.MapaPCB[bloqueado.PID].TCBs[bloqueado.TID]
+	return tcb, existe
+}
+
+// Saca de las colas de los dispositivos las solicitudes que todavia no empezaron; con todos en true son las de todo el proceso.
+// Las que ya están en curso terminan igual, pero como su bloqueo ya no existe no desbloquean a nadie
+func (e *KernelState) Cancelar_solicitudes_IO(pid uint32, tid uint32, todos bool, logger *slog.Logger) {
+	for dispositivo, cola := range e.ColasIO {
+		var nuevaCola []SolicitudIO
+		for _, solicitud := range cola {
+			if solicitud.PID == pid && (todos || solicitud.TID == tid) {
+				logger.Info(fmt.Sprintf("## (%d:%d) - Se cancela la solicitud de IO %d en %s", solicitud.PID, solicitud.TID, solicitud.ID, dispositivo))
+				continue
+			}
+			nuevaCola = append(nuevaCola, solicitud)
+		}
+		e.ColasIO[dispositivo] = nuevaCola
+	}
+}
+
+// Saca de STOPPED los hilos del proceso; con todos en true son todos los del proceso, sino solo el hilo indicado.
+// Devuelve los que sacó
+func (e *KernelState) Quitar_detenidos(pid uint32, tid uint32, todos bool) []types.TCB {
+	var quitados, nuevaCola []types.TCB
+	for _, tcb := range e.ColaStopped {
+		if tcb.PID == pid && (todos || tcb.TID == tid) {
+			quitados = append(quitados, tcb)
+			continue
+		}
+		nuevaCola = append(nuevaCola, tcb)
+	}
+	e.ColaStopped = nuevaCola
+	return quitados
+}
+
+// Arma el registro de finalización del hilo
+func Finalizacion_de_hilo(tcb types.TCB, causa Causa) Finalizacion {
+	return Finalizacion{PID: tcb.PID, TID: tcb.TID, Causa: causa, Creacion: tcb.Creacion, Fin: time.Now(), TiempoCPU: tcb.TiempoCPU}
+}
+
+// Pasa el hilo a la cola de exit con la causa por la que terminó y lo saca de su PCB; si estaba bloqueado se descarta
+// el bloqueo y su IO pendiente
+func (e *KernelState) Finalizar_hilo(pid uint32, tid uint32, causa Causa, logger *slog.Logger) {
+	tcb, existe := e.MapaPCB[pid].TCBs[tid]
+	if !existe {
+		logger.Error(fmt.Sprintf("El TCB con TID %d no existe en el PCB con PID %d", tid, pid))
+		return
+	}
+	for _, bloqueado := range append([]Bloqueado(nil), e.ColaBlocked...) {
+		if bloqueado.PID == pid && bloqueado.TID == tid {
+			Eliminar_TCBs_de_cola_Block_Finalizar_Hilo(bloqueado, &e.ColaBlocked, logger)
+		}
+	}
+	e.Cancelar_solicitudes_IO(pid, tid, false, logger)
+	e.Quitar_detenidos(pid, tid, false)
+
+	Encolar(&e.ColaExit, Finalizacion_de_hilo(tcb, causa))
+	Sacar_TCB_Del_Map(&e.MapaPCB, pid, tid, logger)
+}
+
+// Busca los TCBs del PCB en las colas de Ready y Blocked, los mueve a la cola de Exit y elimina el PCB.
+// Los hilos que quedaban terminan con la misma causa que el proceso
+func (e *KernelState) Finalizar_proceso(pid uint32, quitarDeReady func(pid uint32, tid uint32) bool, causa Causa, logger *slog.Logger) bool {
+
+	pcb := Obtener_PCB_por_PID(pid)
+	if pcb == nil {
+		logger.Error(fmt.Sprintf("No existe el proceso con PID: %d", pid))
+		return false
+	}
+
+	// Elimina TCBs de la cola de ready y blocked si es que hubiera
+	Eliminar_TCBs_de_cola_Ready(pcb, quitarDeReady, logger)
+	Eliminar_TCBs_de_cola_Block(pcb, &e.ColaBlocked, logger)
+	e.Cancelar_solicitudes_IO(pid, 0, true, logger)
+	e.Quitar_detenidos(pid, 0, true)
+	delete(e.Detenidos, pid)
+
+	// Mueve todos los TCBs del PCB a la cola de exit
+	for _, tcb := range pcb.TCBs {
+		e.ColaExit = append(e.ColaExit, Finalizacion_de_hilo(tcb, causa))
+		logger.Info(fmt.Sprintf("TCB con TID %d movido a la cola de Exit", tcb.TID))
+	}
+
+	// El tiempo de CPU del proceso es el de todos sus hilos, también los que finalizaron antes
+	fin := Finalizacion{PID: pid, Causa: causa, Creacion: pcb.Creacion, Fin: time.Now()}
+	for _, hilo := range e.ColaExit {
+		if hilo.PID == pid {
+			fin.TiempoCPU += hilo.TiempoCPU
+		}
+	}
+	e.Finalizados[pid] = fin
+
+	// Limpiar los TCBs del PCB
+	delete(e.MapaPCB, pid)
+	logger.Info(fmt.Sprintf("Todos los TCBs del PCB con PID %d han sido liberados", pcb.PID))
+	return true
+}
+
+// Estado del proceso: NEW si todavia no tiene memoria, STOPPED si lo detuvieron, sino ACTIVO
+func (e *KernelState) Estado_de_proceso(pid uint32) string {
+	for _, new := range e.ColaNew {
+		if new.PCB.PID == pid {
+			return "NEW"
+		}
+	}
+	if e.Detenidos[pid] {
+		return "STOPPED"
+	}
+	return "ACTIVO"
+}
+
+// Estado del hilo: EXEC, BLOCKED, STOPPED, NEW (si su proceso todavia no tiene memoria) o READY
+func (e *KernelState) Estado_de_hilo(pid uint32, tid uint32) string {
+	if Buscar_Execute(pid, tid) != nil {
+		return "EXEC"
+	}
+	for _, bloqueado := range e.ColaBlocked {
+		if bloqueado.PID == pid && bloqueado.TID == tid {
+			return "BLOCKED"
+		}
+	}
+	for _, detenido := range e.ColaStopped {
+		if detenido.PID == pid && detenido.TID == tid {
+			return "STOPPED"
+		}
+	}
+	if e.Estado_de_proceso(pid) == "NEW" {
+		return "NEW"
+	}
+	return "READY"
+}
@@ -11,65 +11,85 @@ import (
 
 // Struct para manejar las peticiones de IO
 type SolicitudIO struct {
-	PID       uint32    `json:"pid"`       // ID del proceso que realizó la solicitud
-	TID       uint32    `json:"tid"`       // ID del hilo que realizó la solicitud
-	Duracion  int       `json:"duracion"`  // Duración de la solicitud (en milisegundos)
-	Timestamp time.Time `json:"timestamp"` // Indica el momento en el que se realizó la solicitud
+	ID          int       `json:"id"`          // Mismo ID que el bloqueo del hilo, para desbloquearlo al terminar
+	Dispositivo string    `json:"dispositivo"` // Nombre del dispositivo que atiende la solicitud
+	PID         uint32    `json:"pid"`         // ID del proceso que realizó la solicitud
+	TID         uint32    `json:"tid"`         // ID del hilo que realizó la solicitud
+	Duracion    int       `json:"duracion"`    // Duración de la solicitud (en milisegundos)
+	Timestamp   time.Time `json:"timestamp"`   // Indica el momento en el que se realizó la solicitud
 }
 
-// Hilo ejecutando actualmente
+// Hilo ejecutando actualmente en una CPU
 type ExecuteActual struct {
-	PID       uint32 `json:"pid"`
-	TID       uint32 `json:"tid"`
-	IDexecute int    `json:"idexecute"`
-}
-
-var Execute *ExecuteActual
-
-// Mapa para almacenar los PCB con su PID como clave
-var MapaPCB map[uint32]types.PCB
-
-// Inicializa el mapa de PCBs
-func InicializarPCBMapGlobal() {
-	MapaPCB = make(map[uint32]types.PCB)
+	PID         uint32    `json:"pid"`
+	TID         uint32    `json:"tid"`
+	IDexecute   int       `json:"idexecute"`
+	Inicio      time.Time `json:"inicio"`      // Momento en que se despachó, para medir la ráfaga
+	CPU         int       `json:"cpu"`         // Posición de la CPU en Configs.CPUs
+	Desalojando bool      `json:"desalojando"` // Ya se le mandó una interrupción (prioridad, señal o finalización) y la CPU todavia no avisó que lo sacó
 }
 
 // Función para obtener el PCB a partir de un PID
 func Obtener_PCB_por_PID(pid uint32) *types.PCB {
-	pcb, existe := MapaPCB[pid]
+	pcb, existe := Estado.MapaPCB[pid]
 	if !existe {
 		return nil
 	}
 	return &pcb
 }
 
-// Funcion para modificar la variable Execute
-func Modificar_Execute(pid uint32, tid uint32) {
-	if Execute == nil {
-		Execute = &ExecuteActual{}
-		Execute.PID = pid
-		Execute.TID = tid
-	} else {
-		Execute.PID = pid
-		Execute.TID = tid
+// Devuelve la posición de la primera CPU libre, -1 si están todas ocupadas
+func CPU_libre() int {
+	for cpu, exec := range Estado.Executes {
+		if exec == nil {
+			return cpu
+		}
 	}
+	return -1
 }
 
-// Elimina los TCBs del PCB de las multiples colas de Ready (no importa cual sea el algoritmo de planificación)
-func Eliminar_TCBs_de_cola_Ready(pcb *types.PCB, colas map[int][]types.TCB, logger *slog.Logger) {
-	// Itera sobre cada cola de prioridad
-	for prioridad, cola := range colas {
-		var nuevaCola []types.TCB
-		// Itera la cola buscando los TCBs que pertenecen al PCB actual
-		for _, tcb := range cola {
-			if tcb.PID != pcb.PID {
-				nuevaCola = append(nuevaCola, tcb) // Mantiene los TCBs que no pertenecen al PCB actual
-			} else {
-				logger.Info(fmt.Sprintf("TCB con TID %d y PID %d eliminado de la cola de prioridad %d", tcb.TID, tcb.PID, prioridad))
-			}
+// Devuelve donde se está ejecutando el hilo, nil si no está ejecutando en ninguna CPU
+func Buscar_Execute(pid uint32, tid uint32) *ExecuteActual {
+	for _, exec := range Estado.Executes {
+		if exec != nil && exec.PID == pid && exec.TID == tid {
+			return exec
+		}
+	}
+	return nil
+}
+
+// Devuelve algún hilo del proceso que esté ejecutando, nil si no hay ninguno
+func Buscar_Execute_de_proceso(pid uint32) *ExecuteActual {
+	for _, exec := range Estado.Executes {
+		if exec != nil && exec.PID == pid {
+			return exec
+		}
+	}
+	return nil
+}
+
+// Indica si hay algún hilo ejecutando en alguna CPU
+func Hay_hilos_ejecutando() bool {
+	return len(Hilos_ejecutando()) > 0
+}
+
+// Devuelve los hilos que están ejecutando, uno por CPU ocupada
+func Hilos_ejecutando() []*ExecuteActual {
+	var ejecutando []*ExecuteActual
+	for _, exec := range Estado.Executes {
+		if exec != nil {
+			ejecutando = append(ejecutando, exec)
+		}
+	}
+	return ejecutando
+}
+
+// Elimina los TCBs del PCB de la cola de Ready; quitar es la función del algoritmo de planificación que sabe donde está cada TCB
+func Eliminar_TCBs_de_cola_Ready(pcb *types.PCB, quitar func(pid uint32, tid uint32) bool, logger *slog.Logger) {
+	for tid := range pcb.TCBs {
+		if quitar(pcb.PID, tid) {
+			logger.Info(fmt.Sprintf("TCB con TID %d y PID %d eliminado de la cola de Ready", tid, pcb.PID))
 		}
-		// Actualiza la cola en el mapa de colas
-		colas[prioridad] = nuevaCola
 	}
 }
 
@@ -88,51 +108,19 @@ func Eliminar_TCBs_de_cola_Block(pcb *types.PCB, cola *[]Bloqueado, logger *slog
 }
 
 func Eliminar_TCBs_de_cola_Block_Finalizar_Hilo(tcb Bloqueado, cola *[]Bloqueado, logger *slog.Logger) {
-	var nuevaCola []Bloqueado
-
-	for _, tcbCola := range *cola {
-		if tcb.PID != tcbCola.PID {
-			nuevaCola = append(nuevaCola, tcbCola) // Mantiene los TCBs que no pertenecen al PCB actual
-		} else if tcb.PID == tcbCola.PID && tcb.TID != tcbCola.TID {
-			nuevaCola = append(nuevaCola, tcbCola)
-		}
+	if _, existe := Desencolar_cola_block(tcb.ID, cola); existe {
 		logger.Info(fmt.Sprintf("TCB con TID %d y PID %d eliminado de la cola de BLOCK", tcb.TID, tcb.PID))
 	}
-	*cola = nuevaCola
-}
-
-// Busca los TCBs del PCB en las colas de Ready y Blocked y los mueve a la cola de Exit
-func Enviar_proceso_a_exit(pid uint32, colaReady map[int][]types.TCB, colaBlocked *[]Bloqueado, colaExit *[]types.TCB, logger *slog.Logger) bool {
-
-	pcb := Obtener_PCB_por_PID(pid)
-	if pcb == nil {
-		logger.Error(fmt.Sprintf("No existe el proceso con PID: %d", pid))
-		return false
-	}
-
-	// Elimina TCBs de la cola de ready y blocked si es que hubiera
-	Eliminar_TCBs_de_cola_Ready(pcb, colaReady, logger)
-	Eliminar_TCBs_de_cola_Block(pcb, colaBlocked, logger)
-
-	// Mueve todos los TCBs del PCB a la cola de exit
-	for _, tcb := range pcb.TCBs {
-		*colaExit = append(*colaExit, tcb)
-		logger.Info(fmt.Sprintf("TCB con TID %d movido a la cola de Exit", tcb.TID))
-	}
-
-	// Limpiar los TCBs del PCB
-	pcb.TCBs = nil
-	delete(MapaPCB, pid)
-	logger.Info(fmt.Sprintf("Todos los TCBs del PCB con PID %d han sido liberados", pcb.PID))
-	return true
 }
 
 // ! Si anda mal probar ponerle los punteors a las colas y el map -- Revisar los punteros de las funciones -- Revisar la asignacion de valores
 // Se lo saque porque en go los map, slices y punteros ya son referencias, por lo cual
 // no es necesario pasarlos como punteros
-func Librerar_Bloqueados_De_Hilo(colaBloqueados *[]Bloqueado, colaReady map[int][]types.TCB, tcb types.TCB, logger *slog.Logger) {
+// encolar es la función del planificador que pasa un TCB a READY.
+// Los mutex que tenía el hilo no se ven acá: los libera el planificador (Liberar_mutexes_de_hilo) con la política de mutex_handoff
+func Librerar_Bloqueados_De_Hilo(colaBloqueados *[]Bloqueado, encolar func(tcb types.TCB), tcb types.TCB, logger *slog.Logger) {
 
-	for _, bloqueado := range *colaBloqueados {
+	for _, bloqueado := range append([]Bloqueado(nil), *colaBloqueados...) {
 
 		if bloqueado.PID == tcb.PID && bloqueado.Motivo == THREAD_JOIN {
 			num, err := strconv.ParseUint(bloqueado.QuienFue, 10, 32)
@@ -142,22 +130,65 @@ func Librerar_Bloqueados_De_Hilo(colaBloqueados *[]Bloqueado, colaReady map[int]
 			num32 := uint32(num)
 			if num32 == tcb.TID {
 				Eliminar_TCBs_de_cola_Block_Finalizar_Hilo(bloqueado, colaBloqueados, logger)
-				Encolar_ColaReady(colaReady, MapaPCB[bloqueado.PID].TCBs[bloqueado.TID])
+				encolar(Estado.MapaPCB[bloqueado.PID].TCBs[bloqueado.TID])
 				logger.Info(fmt.Sprintf("TCB con TID %d y PID %d, Bloqueado por THREAD_JOIN movido a la cola de Ready", bloqueado.TID, bloqueado.PID))
 			}
-		} else if bloqueado.PID == tcb.PID && bloqueado.Motivo == Mutex {
-
-			if MapaPCB[tcb.PID].Mutexs[bloqueado.QuienFue] == strconv.Itoa(int(tcb.TID)) {
-				MapaPCB[bloqueado.PID].Mutexs[bloqueado.QuienFue] = strconv.Itoa(int(bloqueado.TID))
-				Eliminar_TCBs_de_cola_Block_Finalizar_Hilo(bloqueado, colaBloqueados, logger)
-				Encolar_ColaReady(colaReady, MapaPCB[bloqueado.PID].TCBs[bloqueado.TID])
-				logger.Info(fmt.Sprintf("TCB con TID %d y PID %d, Bloqueado por Mutex movido a la cola de Ready", bloqueado.TID, bloqueado.PID))
-			}
 		}
 	}
 
 }
 
+// Código de salida por defecto de cada forma de terminar, con la convención de Unix.
+// El de un proceso es el que recibe el padre con PROCESS_WAIT
+const (
+	SALIDA_OK       = 0   // PROCESS_EXIT o THREAD_EXIT sin código
+	SALIDA_ERROR    = 1   // Usó un recurso que no existe o falló el dump de memoria
+	SALIDA_KILL     = 137 // 128 + SIGKILL: señales, THREAD_CANCEL o recuperación de un deadlock
+	SALIDA_SEGFAULT = 139 // 128 + SIGSEGV
+)
+
+// Por qué terminó un hilo o un proceso y con qué código de salida
+type Causa struct {
+	Motivo string `json:"motivo"`
+	Codigo int    `json:"codigo"`
+}
+
+// Causas de finalización que no eligió el propio hilo (PROCESS_EXIT y THREAD_EXIT llevan el código que pasa el hilo)
+var (
+	FIN_RECURSO_INEXISTENTE = Causa{Motivo: "RECURSO_INEXISTENTE", Codigo: SALIDA_ERROR}
+	FIN_DUMP_FALLIDO        = Causa{Motivo: "DUMP_FALLIDO", Codigo: SALIDA_ERROR}
+	FIN_SEGMENTATION_FAULT  = Causa{Motivo: "SEGMENTATION_FAULT", Codigo: SALIDA_SEGFAULT}
+	FIN_THREAD_CANCEL       = Causa{Motivo: "THREAD_CANCEL", Codigo: SALIDA_KILL}
+	FIN_PROCESS_KILL        = Causa{Motivo: "PROCESS_KILL", Codigo: SALIDA_KILL}
+	FIN_THREAD_KILL         = Causa{Motivo: "THREAD_KILL", Codigo: SALIDA_KILL}
+	FIN_DEADLOCK            = Causa{Motivo: "DEADLOCK", Codigo: SALIDA_KILL}
+)
+
+// Registro de como terminó un hilo (en ColaExit) o un proceso
+type Finalizacion struct {
+	PID uint32 `json:"pid"`
+	TID uint32 `json:"tid"` // En el registro de un proceso no se usa
+	Causa
+	Creacion  time.Time `json:"creacion"`
+	Fin       time.Time `json:"fin"`
+	TiempoCPU float64   `json:"tiempo_cpu"` // Milisegundos que ejecutó; en un proceso la suma de todos sus hilos
+}
+
+// Proceso que finalizó y todavia no fue esperado por su padre con PROCESS_WAIT
+type Zombie struct {
+	PID    uint32 `json:"pid"`
+	Padre  uint32 `json:"padre"`
+	Estado int    `json:"estado"`
+}
+
+// Señal mandada a un proceso (o a un hilo con THREAD_KILL). Tipo es el nombre de la syscall:
+// PROCESS_KILL, THREAD_KILL, PROCESS_STOP o PROCESS_CONT
+type Senial struct {
+	Tipo string `json:"tipo"`
+	PID  uint32 `json:"pid"`
+	TID  uint32 `json:"tid"` // Solo para THREAD_KILL
+}
+
 // Estructuras para manejar los bloqueados
 type Motivo int
 
@@ -166,14 +197,64 @@ const ( // Esto funciona mas o menos como el enum de c
 	Mutex                     // Vale 1
 	IO                        // Vale 2
 	DUMP                      // Vale 3
+	Semaforo                  // Vale 4
+	Condicion                 // Vale 5
+	Lectura                   // Vale 6
+	Escritura                 // Vale 7
+	Barrera                   // Vale 8
+	Recurso                   // Vale 9
+	MQVacia                   // Vale 10
+	MQLlena                   // Vale 11
+	Espera                    // Vale 12
+	Periodo                   // Vale 13
 )
 
+// Nombre del motivo como aparece en los logs de bloqueo
+func (m Motivo) String() string {
+	switch m {
+	case THREAD_JOIN:
+		return "THREAD_JOIN"
+	case Mutex:
+		return "MUTEX"
+	case IO:
+		return "IO"
+	case DUMP:
+		return "DUMP MEMORY"
+	case Semaforo:
+		return "SEMAFORO"
+	case Condicion:
+		return "CONDICION"
+	case Lectura:
+		return "RWLOCK LECTURA"
+	case Escritura:
+		return "RWLOCK ESCRITURA"
+	case Barrera:
+		return "BARRERA"
+	case Recurso:
+		return "RECURSO"
+	case MQVacia:
+		return "MQ_RECV"
+	case MQLlena:
+		return "MQ_SEND"
+	case Espera:
+		return "PROCESS_WAIT"
+	case Periodo:
+		return "THREAD_JOB_END"
+	}
+	return fmt.Sprintf("MOTIVO %d", int(m))
+}
+
 // Como no se puede hacer un slice con un struc generico, hago que el QuienFue sea un string
 // Y cuando necesite que sea un uint32 lo parseo
 // ACLARACIONES: EL QUIENFUE SE PASA SIEMPRE COMO STRING
+// Cada bloqueo tiene un ID único (lo asigna KernelState.Bloquear_hilo) y se desbloquea por ese ID,
+// asi el que termina una espera (por ejemplo una solicitud de IO) libera exactamente al hilo que la hizo
 type Bloqueado struct {
+	ID       int    `json:"id"`
 	PID      uint32 `json:"pid"`
 	TID      uint32 `json:"tid"`
 	Motivo   Motivo `json:"motivo"`
-	QuienFue string `json:"quien_fue"` // si es THREAD_JOIN es un uint32, si es Mutex es un string
+	QuienFue string `json:"quien_fue"` // si es THREAD_JOIN o Espera es un uint32 (TID o PID), en los demás motivos es el nombre del recurso
+	Mutex    string `json:"mutex"`     // si es Condicion, el mutex que el hilo vuelve a tomar al despertarse
+	Cantidad int    `json:"cantidad"`  // si es Recurso, las instancias que pidió
 }
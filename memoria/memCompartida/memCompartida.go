@@ -0,0 +1,146 @@
+package memCompartida
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"github.com/sisoputnfrba/tp-golang/memoria/memUsuario"
+	"github.com/sisoputnfrba/tp-golang/memoria/utils"
+	"github.com/sisoputnfrba/tp-golang/utils/types"
+)
+
+// Los segmentos compartidos se reservan en una zona al final de la memoria de usuario (shared_memory_size bytes),
+// aparte de las particiones, asi la compactación no los mueve. Todos los procesos ven un segmento en la misma
+// dirección lógica, que es su dirección física: la zona empieza donde termina la memoria de usuario, asi que queda
+// por encima de cualquier dirección lógica de una partición sea cual sea memory_size. Cada segmento cuenta los
+// procesos que lo usan (el que lo crea y los que lo adjuntan) y se libera cuando finaliza el último
+
+type segmento struct {
+	desplazamiento uint32 // Comienzo dentro de la zona compartida
+	tamanio        uint32
+	procesos       map[uint32]bool // PIDs que lo usan
+}
+
+var (
+	ErrYaExiste   = errors.New("el segmento compartido ya existe")
+	ErrNoExiste   = errors.New("el segmento compartido no existe")
+	ErrSinEspacio = errors.New("no hay espacio en la zona de memoria compartida")
+)
+
+var mu sync.Mutex
+var inicioZona uint32
+var tamanioZona uint32
+var Segmentos = make(map[string]*segmento)
+
+// Agrega la zona compartida al final de la memoria de usuario (se llama despues de inicializar las particiones)
+func Inicializar_Memoria_Compartida(logger *slog.Logger) {
+	inicioZona = uint32(len(memUsuario.MemoriaDeUsuario))
+	tamanioZona = uint32(max(utils.Configs.SharedMemory, 0))
+	memUsuario.MemoriaDeUsuario = append(memUsuario.MemoriaDeUsuario, make([]byte, tamanioZona)...)
+	logger.Info(fmt.Sprintf("Memoria compartida inicializada: ## Base = %d, Límite = %d", inicioZona, tamanioZona))
+}
+
+// Reserva el segmento con first fit dentro de la zona y lo deja en uso por el proceso que lo crea
+func Crear_segmento(pid uint32, nombre string, tamanio int) error {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, existe := Segmentos[nombre]; existe {
+		return ErrYaExiste
+	}
+	desplazamiento, hayHueco := buscarHueco(uint32(tamanio))
+	if tamanio <= 0 || !hayHueco {
+		return ErrSinEspacio
+	}
+
+	Segmentos[nombre] = &segmento{desplazamiento: desplazamiento, tamanio: uint32(tamanio), procesos: map[uint32]bool{pid: true}}
+	clear(memUsuario.MemoriaDeUsuario[inicioZona+desplazamiento : inicioZona+desplazamiento+uint32(tamanio)])
+	return nil
+}
+
+// Primer hueco de la zona donde entra el tamaño pedido
+func buscarHueco(tamanio uint32) (uint32, bool) {
+	var ocupados []*segmento
+	for _, s := range Segmentos {
+		ocupados = append(ocupados, s)
+	}
+	sort.Slice(ocupados, func(i, j int) bool { return ocupados[i].desplazamiento < ocupados[j].desplazamiento })
+
+	var desde uint32
+	for _, s := range ocupados {
+		if s.desplazamiento-desde >= tamanio {
+			return desde, true
+		}
+		desde = s.desplazamiento + s.tamanio
+	}
+	return desde, tamanioZona-desde >= tamanio
+}
+
+// Suma al proceso a los que usan el segmento y devuelve cómo traducir sus direcciones
+func Adjuntar_segmento(pid uint32, nombre string) (types.SegmentoAdjunto, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	s, existe := Segmentos[nombre]
+	if !existe {
+		return types.SegmentoAdjunto{}, ErrNoExiste
+	}
+	s.procesos[pid] = true
+	return s.adjunto(nombre), nil
+}
+
+// Segmentos que usa el proceso, para mandarle a la CPU junto con el contexto
+func Segmentos_de_proceso(pid uint32) []types.SegmentoAdjunto {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var adjuntos []types.SegmentoAdjunto
+	for nombre, s := range Segmentos {
+		if s.procesos[pid] {
+			adjuntos = append(adjuntos, s.adjunto(nombre))
+		}
+	}
+	return adjuntos
+}
+
+// Quita al proceso de los segmentos que usaba y libera los que quedan sin nadie
+func Liberar_segmentos_de_proceso(pid uint32, logger *slog.Logger) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for nombre, s := range Segmentos {
+		if !s.procesos[pid] {
+			continue
+		}
+		delete(s.procesos, pid)
+		if len(s.procesos) == 0 {
+			delete(Segmentos, nombre)
+			logger.Info(fmt.Sprintf("## Segmento compartido %s liberado - Base: %d - Tamaño: %d", nombre, inicioZona+s.desplazamiento, s.tamanio))
+		}
+	}
+}
+
+// Indica si los tamanio bytes desde la dirección física caen dentro de un mismo segmento compartido
+func Contiene_direccion(direccionFisica uint32, tamanio uint32) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, s := range Segmentos {
+		if direccionFisica >= inicioZona+s.desplazamiento && direccionFisica+tamanio <= inicioZona+s.desplazamiento+s.tamanio {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *segmento) adjunto(nombre string) types.SegmentoAdjunto {
+	return types.SegmentoAdjunto{
+		Nombre: nombre,
+		Base:   inicioZona + s.desplazamiento,
+		Fisica: inicioZona + s.desplazamiento,
+		Limite: s.tamanio,
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"github.com/sisoputnfrba/tp-golang/memoria/memCompartida"
 	"github.com/sisoputnfrba/tp-golang/memoria/memUsuario"
 	"github.com/sisoputnfrba/tp-golang/memoria/server"
 	"github.com/sisoputnfrba/tp-golang/memoria/utils"
@@ -21,6 +22,7 @@ func main() {
 	} else {
 		logger.Info("mal definido el esquema de particiones")
 	}
+	memCompartida.Inicializar_Memoria_Compartida(logger)
 
 	// Inicializamos la memoria (Lo levantamos como servidor)
 	server.Iniciar_memoria(logger)
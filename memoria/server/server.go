@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/sisoputnfrba/tp-golang/memoria/client"
+	"github.com/sisoputnfrba/tp-golang/memoria/memCompartida"
 	"github.com/sisoputnfrba/tp-golang/memoria/memSistema"
 	"github.com/sisoputnfrba/tp-golang/memoria/memUsuario"
 	"github.com/sisoputnfrba/tp-golang/memoria/utils"
@@ -27,6 +28,8 @@ func Iniciar_memoria(logger *slog.Logger) {
 	mux.HandleFunc("POST /FINALIZAR_HILO", FinalizarHilo(logger))
 	mux.HandleFunc("POST /MEMORY-DUMP", MemoryDump(logger))
 	mux.HandleFunc("POST /compactar", Compactar(logger))
+	mux.HandleFunc("POST /SHM_CREATE", Crear_memoria_compartida(logger))
+	mux.HandleFunc("POST /SHM_ATTACH", Adjuntar_memoria_compartida(logger))
 
 	// Comunicacion con CPU
 	mux.HandleFunc("POST /contexto", Obtener_Contexto_De_Ejecucion(logger))
@@ -97,6 +100,8 @@ func FinalizarProceso(logger *slog.Logger) http.HandlerFunc {
 
 		//marca la particion como libre en memoria de usuario
 		memUsuario.LiberarParticionPorPID(pidUint32, logger)
+		// Deja de usar los segmentos compartidos (se liberan si era el último)
+		memCompartida.Liberar_segmentos_de_proceso(pidUint32, logger)
 		// Ejecutar la función para eliminar el contexto del PID en Memoria de sistema
 		memSistema.EliminarContextoPID(pidUint32)
 		// Log de destrucción del proceso
@@ -257,6 +262,55 @@ func MemoryDump(logger *slog.Logger) http.HandlerFunc {
 	}
 }
 
+// Crea un segmento de memoria compartida; responde 409 si ya existe y 507 si no hay lugar en la zona compartida
+func Crear_memoria_compartida(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var pedido types.PedidoSHM
+		err := json.NewDecoder(r.Body).Decode(&pedido)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+			http.Error(w, "Error al decodificar mensaje", http.StatusBadRequest)
+			return
+		}
+
+		err = memCompartida.Crear_segmento(pedido.PID, pedido.Nombre, pedido.Tamanio)
+		switch err {
+		case nil:
+			logger.Info(fmt.Sprintf("## Segmento compartido creado - PID: %d - Nombre: %s - Tamaño: %d", pedido.PID, pedido.Nombre, pedido.Tamanio))
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("OK"))
+		case memCompartida.ErrYaExiste:
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			logger.Info(fmt.Sprintf("No se pudo crear el segmento %s: %s", pedido.Nombre, err.Error()))
+			http.Error(w, err.Error(), http.StatusInsufficientStorage)
+		}
+	}
+}
+
+// Adjunta el segmento al proceso y responde con los datos para traducir sus direcciones; 404 si no existe
+func Adjuntar_memoria_compartida(logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var pedido types.PedidoSHM
+		err := json.NewDecoder(r.Body).Decode(&pedido)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Error al decodificar mensaje: %s\n", err.Error()))
+			http.Error(w, "Error al decodificar mensaje", http.StatusBadRequest)
+			return
+		}
+
+		adjunto, err := memCompartida.Adjuntar_segmento(pedido.PID, pedido.Nombre)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		logger.Info(fmt.Sprintf("## Segmento compartido adjuntado - PID: %d - Nombre: %s - Dir. Lógica: %d", pedido.PID, pedido.Nombre, adjunto.Base))
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(adjunto)
+	}
+}
+
 func Compactar(logger *slog.Logger) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -307,17 +361,18 @@ func Obtener_Contexto_De_Ejecucion(logger *slog.Logger) http.HandlerFunc {
 
 		// Crear el contexto completo usando la estructura que CPU espera (RegCPU)
 		contextoCompleto := types.RegCPU{
-			PC:     contextoTID.PC,
-			AX:     contextoTID.AX,
-			BX:     contextoTID.BX,
-			CX:     contextoTID.CX,
-			DX:     contextoTID.DX,
-			EX:     contextoTID.EX,
-			FX:     contextoTID.FX,
-			GX:     contextoTID.GX,
-			HX:     contextoTID.HX,
-			Base:   contextoPID.Base,
-			Limite: contextoPID.Limite,
+			PC:        contextoTID.PC,
+			AX:        contextoTID.AX,
+			BX:        contextoTID.BX,
+			CX:        contextoTID.CX,
+			DX:        contextoTID.DX,
+			EX:        contextoTID.EX,
+			FX:        contextoTID.FX,
+			GX:        contextoTID.GX,
+			HX:        contextoTID.HX,
+			Base:      contextoPID.Base,
+			Limite:    contextoPID.Limite,
+			Segmentos: memCompartida.Segmentos_de_proceso(pidTid.PID),
 		}
 
 		// Codificar el contexto completo como JSON y enviarlo como respuesta
@@ -387,6 +442,16 @@ func Obtener_Instrucción(logger *slog.Logger) http.HandlerFunc {
 	}
 }
 
+// Indica si los tamanio bytes desde la dirección física caen dentro de una misma partición o de un segmento compartido
+func direccionValida(direccionFisica uint32, tamanio uint32) bool {
+	for _, particion := range memUsuario.Particiones {
+		if direccionFisica >= particion.Base && direccionFisica+tamanio <= particion.Base+particion.Limite {
+			return true
+		}
+	}
+	return memCompartida.Contiene_direccion(direccionFisica, tamanio)
+}
+
 func Read_Mem(logger *slog.Logger) http.HandlerFunc {
 
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -407,6 +472,12 @@ func Read_Mem(logger *slog.Logger) http.HandlerFunc {
 			return
 		}
 
+		// Verificar que los 4 bytes estén dentro de una misma partición o segmento compartido
+		if !direccionValida(requestData.DireccionFisica, 4) {
+			http.Error(w, fmt.Sprintf("Dirección física fuera de rango de particiones. Dirección solicitada: %d", requestData.DireccionFisica), http.StatusBadRequest)
+			return
+		}
+
 		// Verificar que la dirección esté dentro de los límites de memoria
 		if requestData.DireccionFisica+4 > uint32(len(memUsuario.MemoriaDeUsuario)) {
 			// Si hay error al buscar en la memoria, enviar una respuesta con error
@@ -460,15 +531,8 @@ func Write_Mem(logger *slog.Logger) http.HandlerFunc {
 			return
 		}
 
-		// Verificar si la dirección física está dentro de alguna partición
-		encontrado := false
-		for _, particion := range memUsuario.Particiones {
-			if requestData.DireccionFisica >= particion.Base && requestData.DireccionFisica < particion.Base+particion.Limite {
-				encontrado = true
-				break
-			}
-		}
-		if !encontrado {
+		// Verificar que los 4 bytes estén dentro de una misma partición o segmento compartido
+		if !direccionValida(requestData.DireccionFisica, 4) {
 			logger.Error("Dirección física fuera de rango de particiones")
 			http.Error(w, "Dirección física fuera de rango de particiones", http.StatusBadRequest)
 			return
@@ -9,6 +9,7 @@ import (
 type Config struct {
 	Port            int    `json:"port"`
 	MemorySize      int    `json:"memory_size"`
+	SharedMemory    int    `json:"shared_memory_size"` // Bytes que se reservan despues de la memoria de usuario para los segmentos compartidos
 	InstructionPath string `json:"instruction_path"`
 	ResponseDelay   int    `json:"response_delay"`
 	IpKernel        string `json:"ip_kernel"`
@@ -1,6 +1,8 @@
 package generadores
 
 import (
+	"time"
+
 	"github.com/sisoputnfrba/tp-golang/kernel/utils"
 	"github.com/sisoputnfrba/tp-golang/utils/types"
 )
@@ -15,15 +17,23 @@ func Generar_PID() uint32 {
 	return PidCounter
 }
 
-// Genera un PCB con un PID único y con las listas de TCBs y Mutexs vacías.
-func Generar_PCB() types.PCB {
+// Genera un PCB con un PID único, hijo del proceso padre (0 si lo crea el kernel), y con las listas de TCBs y recursos de sincronización vacías.
+func Generar_PCB(padre uint32) types.PCB {
 	mutex := make(map[string]string)
 	tcbs := make(map[uint32]types.TCB)
 
 	pcb := types.PCB{
-		PID:    Generar_PID(),
-		TCBs:   tcbs,
-		Mutexs: mutex,
+		PID:         Generar_PID(),
+		Padre:       padre,
+		Creacion:    time.Now(),
+		TCBs:        tcbs,
+		Mutexs:      mutex,
+		Semaforos:   make(map[string]int),
+		Condiciones: make(map[string]bool),
+		RWLocks:     make(map[string]types.RWLock),
+		Barreras:    make(map[string]int),
+		Reclamos:    make(map[string]int),
+		Asignados:   make(map[string]int),
 	}
 
 	MapaParaTCBS[pcb.PID] = 0
@@ -43,10 +53,13 @@ func Generar_TCB(pcb *types.PCB, prioridad int) types.TCB {
 	}
 
 	tcb := types.TCB{
-		TID:       tid,
-		Prioridad: prioridad,
-		PID:       pcb.PID,
-		Quantum:   utils.Configs.Quantum,
+		TID:           tid,
+		Prioridad:     prioridad,
+		PrioridadBase: prioridad,
+		PID:           pcb.PID,
+		Quantum:       utils.Configs.Quantum,
+		Estimacion:    float64(utils.Configs.InitialBurst),
+		Creacion:      time.Now(),
 	}
 
 	pcb.TCBs[tid] = tcb
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type HandShake struct {
 	Mensaje string `json:"mensaje"`
 }
@@ -9,16 +11,46 @@ type EstructuraEmpty struct {
 
 // --------------------------------- KERNEL ---------------------------------
 type PCB struct {
-	PID    uint32            `json:"pid"`
-	TCBs   map[uint32]TCB    `json:"tcb"`
-	Mutexs map[string]string `json:"mutexs"` // Clave: nombre mutex, Valor: estado del mutex (libre/tid que lo contiene)
+	PID         uint32            `json:"pid"`
+	Padre       uint32            `json:"padre"` // PID del proceso que lo creó con PROCESS_CREATE (0 si lo creó el kernel)
+	Creacion    time.Time         `json:"creacion"`
+	TCBs        map[uint32]TCB    `json:"tcb"`
+	Mutexs      map[string]string `json:"mutexs"`      // Clave: nombre mutex, Valor: estado del mutex (libre/tid que lo contiene)
+	Semaforos   map[string]int    `json:"semaforos"`   // Clave: nombre del semáforo, Valor: contador
+	Condiciones map[string]bool   `json:"condiciones"` // Variables de condición creadas (los hilos que esperan están en la cola de bloqueados)
+	RWLocks     map[string]RWLock `json:"rwlocks"`     // Clave: nombre del rwlock
+	Barreras    map[string]int    `json:"barreras"`    // Clave: nombre de la barrera, Valor: cantidad de hilos que tienen que llegar
+	Reclamos    map[string]int    `json:"reclamos"`    // Máximo declarado de cada recurso del banquero (RESOURCE_DECLARE)
+	Asignados   map[string]int    `json:"asignados"`   // Instancias de cada recurso del banquero que tiene el proceso
+}
+
+// Lock de lectores/escritores: lo pueden tener varios lectores a la vez o un único escritor
+type RWLock struct {
+	Lectores []uint32 `json:"lectores"` // TIDs que lo tienen tomado en lectura
+	Escritor string   `json:"escritor"` // TID que lo tiene tomado en escritura, LIBRE si no hay
 }
 
 type TCB struct {
-	TID       uint32 `json:"tid"` // EL TID TAMBIEN ES SU POSICION EN EL SLICE DE TCBs
-	Prioridad int    `json:"prioridad"`
-	PID       uint32 `json:"pid"` //PID del proceso al que pertenece
-	Quantum   int    `json:"quantum"`
+	TID           uint32    `json:"tid"`            // EL TID TAMBIEN ES SU POSICION EN EL SLICE DE TCBs
+	Prioridad     int       `json:"prioridad"`      // Prioridad efectiva, la que usan los planificadores
+	PrioridadBase int       `json:"prioridad_base"` // Prioridad propia del hilo; la efectiva puede estar elevada por los mutex que tiene (mutex_protocol)
+	PID           uint32    `json:"pid"`            //PID del proceso al que pertenece
+	Quantum       int       `json:"quantum"`
+	Estimacion    float64   `json:"estimacion"`    // Estimación de la proxima ráfaga de CPU (en milisegundos)
+	RafagaActual  float64   `json:"rafaga_actual"` // Lo que lleva ejecutado de la ráfaga actual si fue desalojado antes de terminarla
+	Nivel         int       `json:"nivel"`         // Nivel actual en el MLFQ (0 es el mas prioritario)
+	Tickets       int       `json:"tickets"`       // Tickets para LOTERIA y STRIDE (0 = se calculan a partir de la prioridad)
+	Pase          float64   `json:"pase"`          // Pase acumulado en STRIDE
+	VRuntime      float64   `json:"vruntime"`      // Tiempo de ejecución virtual en CFS (ms ponderados por prioridad)
+	TiempoCPU     float64   `json:"tiempo_cpu"`    // Total ejecutado en todas sus ráfagas (en milisegundos)
+	Creacion      time.Time `json:"creacion"`
+	// Hilos periódicos de tiempo real (THREAD_SET_RT); un periodo 0 indica que es un hilo normal
+	Periodo              int       `json:"periodo"`               // Periodo en milisegundos
+	Plazo                int       `json:"plazo"`                 // Plazo relativo al comienzo de cada trabajo, en milisegundos
+	WCET                 int       `json:"wcet"`                  // Tiempo de ejecución en el peor caso, en milisegundos
+	Liberacion           time.Time `json:"liberacion"`            // Comienzo del trabajo actual
+	Vencimiento          time.Time `json:"vencimiento"`           // Plazo absoluto del trabajo actual
+	VencimientosPerdidos int       `json:"vencimientos_perdidos"` // Trabajos que terminaron despues de su plazo
 }
 
 type PathTamanio struct {
@@ -70,17 +102,34 @@ type RespuestaDump struct {
 
 // --------------------------------- CPU ---------------------------------
 type RegCPU struct {
-	PC     uint32 `json:"pc"`     // Program Counter (Proxima instruccion a ejecutar)
-	AX     uint32 `json:"ax"`     // Registro Numerico de proposito general
-	BX     uint32 `json:"bx"`     // Registro Numerico de proposito general
-	CX     uint32 `json:"cx"`     // Registro Numerico de proposito general
-	DX     uint32 `json:"dx"`     // Registro Numerico de proposito general
-	EX     uint32 `json:"ex"`     // Registro Numerico de proposito general
-	FX     uint32 `json:"fx"`     // Registro Numerico de proposito general
-	GX     uint32 `json:"gx"`     // Registro Numerico de proposito general
-	HX     uint32 `json:"hx"`     // Registro Numerico de proposito general
-	Base   uint32 `json:"base"`   // Direccion base de la particion del proceso
-	Limite uint32 `json:"limite"` // Tamanio de la particion del proceso
+	PC        uint32            `json:"pc"`        // Program Counter (Proxima instruccion a ejecutar)
+	AX        uint32            `json:"ax"`        // Registro Numerico de proposito general
+	BX        uint32            `json:"bx"`        // Registro Numerico de proposito general
+	CX        uint32            `json:"cx"`        // Registro Numerico de proposito general
+	DX        uint32            `json:"dx"`        // Registro Numerico de proposito general
+	EX        uint32            `json:"ex"`        // Registro Numerico de proposito general
+	FX        uint32            `json:"fx"`        // Registro Numerico de proposito general
+	GX        uint32            `json:"gx"`        // Registro Numerico de proposito general
+	HX        uint32            `json:"hx"`        // Registro Numerico de proposito general
+	Base      uint32            `json:"base"`      // Direccion base de la particion del proceso
+	Limite    uint32            `json:"limite"`    // Tamanio de la particion del proceso
+	Segmentos []SegmentoAdjunto `json:"segmentos"` // Segmentos de memoria compartida que adjuntó el proceso
+}
+
+// Segmento de memoria compartida adjuntado por un proceso: las direcciones lógicas [Base, Base+Limite)
+// se traducen a Fisica + desplazamiento
+type SegmentoAdjunto struct {
+	Nombre string `json:"nombre"`
+	Base   uint32 `json:"base"`
+	Fisica uint32 `json:"fisica"`
+	Limite uint32 `json:"limite"`
+}
+
+// Pedido de memoria compartida que el kernel le hace a memoria (SHM_CREATE y SHM_ATTACH)
+type PedidoSHM struct {
+	PID     uint32 `json:"pid"`
+	Nombre  string `json:"nombre"`
+	Tamanio int    `json:"tamanio"`
 }
 
 type Proceso struct {